@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"techno/internal/service"
+	desc "techno/pkg/auth_v1"
+)
+
+type Implementation struct {
+	desc.UnimplementedAuthV1Server
+	authService service.AuthService
+}
+
+func NewImplementation(authService service.AuthService) *Implementation {
+	return &Implementation{
+		authService: authService,
+	}
+}
+
+func (i *Implementation) Register(ctx context.Context, req *desc.RegisterRequest) (*desc.RegisterResponse, error) {
+	user, err := i.authService.Register(ctx, req.GetLogin(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+
+	return &desc.RegisterResponse{
+		Id:    int64(user.ID),
+		Login: user.Login,
+	}, nil
+}
+
+func (i *Implementation) Login(ctx context.Context, req *desc.LoginRequest) (*desc.LoginResponse, error) {
+	token, err := i.authService.Login(ctx, req.GetLogin(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+
+	return &desc.LoginResponse{Token: token}, nil
+}
+
+func (i *Implementation) Logout(ctx context.Context, req *desc.LogoutRequest) (*desc.LogoutResponse, error) {
+	if err := i.authService.Logout(ctx, req.GetToken()); err != nil {
+		return nil, err
+	}
+
+	return &desc.LogoutResponse{}, nil
+}
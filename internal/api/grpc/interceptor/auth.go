@@ -0,0 +1,81 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"techno/internal/auth"
+	"techno/internal/service"
+	authDesc "techno/pkg/auth_v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const authorizationHeader = "authorization"
+
+type AuthInterceptor struct {
+	authService service.AuthService
+}
+
+func NewAuthInterceptor(authService service.AuthService) *AuthInterceptor {
+	return &AuthInterceptor{
+		authService: authService,
+	}
+}
+
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := i.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := i.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (i *AuthInterceptor) authenticate(ctx context.Context) (context.Context, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(authorizationHeader); len(values) > 0 {
+			token, _ = strings.CutPrefix(values[0], "Bearer ")
+		}
+	}
+
+	user, err := i.authService.Authenticate(ctx, strings.TrimSpace(token))
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.WithPrincipal(ctx, auth.FromUser(user)), nil
+}
+
+func isPublic(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+authDesc.AuthV1_ServiceDesc.ServiceName+"/")
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
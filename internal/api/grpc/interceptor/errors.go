@@ -0,0 +1,57 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorsUnary converts service errors into gRPC status codes.
+func ErrorsUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(info.FullMethod, err)
+	}
+	return resp, nil
+}
+
+func ErrorsStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, ss); err != nil {
+		return toStatus(info.FullMethod, err)
+	}
+	return nil
+}
+
+func toStatus(method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Internal
+	switch {
+	case errors.Is(err, model.ErrInvalidInput):
+		code = codes.InvalidArgument
+	case errors.Is(err, model.ErrUnauthenticated), errors.Is(err, model.ErrInvalidCredentials):
+		code = codes.Unauthenticated
+	case errors.Is(err, model.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, model.ErrAlreadyExists):
+		code = codes.AlreadyExists
+	case errors.Is(err, model.ErrInvalidTransition), errors.Is(err, model.ErrHasSubtasks), errors.Is(err, model.ErrConflict):
+		code = codes.FailedPrecondition
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	}
+
+	if code == codes.Internal {
+		log := logger.GetLogger("api.grpc")
+		log.Error().Err(err).Str("method", method).Msg("gRPC call failed")
+	}
+
+	return status.Error(code, err.Error())
+}
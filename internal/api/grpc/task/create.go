@@ -0,0 +1,18 @@
+package task
+
+import (
+	"context"
+	"techno/internal/converter"
+	desc "techno/pkg/task_v1"
+)
+
+func (i *Implementation) CreateTask(ctx context.Context, req *desc.CreateTaskRequest) (*desc.CreateTaskResponse, error) {
+	task := converter.ToTaskFromCreateRequest(req)
+	if err := i.taskService.CreateTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	return &desc.CreateTaskResponse{
+		Task: converter.ToTaskFromService(task),
+	}, nil
+}
@@ -0,0 +1,17 @@
+package task
+
+import (
+	"context"
+	"techno/internal/model"
+	desc "techno/pkg/task_v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (i *Implementation) DeleteTask(ctx context.Context, req *desc.DeleteTaskRequest) (*emptypb.Empty, error) {
+	if err := i.taskService.DeleteTask(ctx, int(req.GetId()), model.ChildPolicy(req.GetChildren())); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,18 @@
+package task
+
+import (
+	"context"
+	"techno/internal/converter"
+	desc "techno/pkg/task_v1"
+)
+
+func (i *Implementation) GetTask(ctx context.Context, req *desc.GetTaskRequest) (*desc.GetTaskResponse, error) {
+	task, err := i.taskService.GetByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &desc.GetTaskResponse{
+		Task: converter.ToTaskFromService(task),
+	}, nil
+}
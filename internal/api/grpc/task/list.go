@@ -0,0 +1,25 @@
+package task
+
+import (
+	"techno/internal/converter"
+	desc "techno/pkg/task_v1"
+)
+
+func (i *Implementation) ListTasks(req *desc.ListTasksRequest, stream desc.TaskV1_ListTasksServer) error {
+	filter, err := converter.ToFilterFromListRequest(req)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := i.taskService.List(stream.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if err := stream.Send(converter.ToTaskFromService(task)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package task
+
+import (
+	"techno/internal/service"
+	desc "techno/pkg/task_v1"
+)
+
+type Implementation struct {
+	desc.UnimplementedTaskV1Server
+	taskService service.TaskService
+}
+
+func NewImplementation(taskService service.TaskService) *Implementation {
+	return &Implementation{
+		taskService: taskService,
+	}
+}
@@ -0,0 +1,55 @@
+package task
+
+import (
+	"context"
+	"techno/internal/converter"
+	"techno/internal/model"
+	desc "techno/pkg/task_v1"
+)
+
+func (i *Implementation) UpdateTask(ctx context.Context, req *desc.UpdateTaskRequest) (*desc.UpdateTaskResponse, error) {
+	task, err := i.taskService.GetByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		task.Title = req.GetTitle().GetValue()
+	}
+	if req.Description != nil {
+		task.Description = req.GetDescription().GetValue()
+	}
+	if req.GetStatus() != desc.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		status, err := converter.ToStatusFromDesc(req.GetStatus())
+		if err != nil {
+			return nil, err
+		}
+		task.Status = status
+	}
+	if req.GetPriority() != desc.TaskPriority_TASK_PRIORITY_UNSPECIFIED {
+		task.Priority = model.TaskPriority(req.GetPriority())
+	}
+	if req.Tags != nil {
+		task.Tags = req.GetTags().GetTags()
+	}
+	if req.Project != nil {
+		task.ProjectKey = req.GetProject().GetValue()
+	}
+	if req.ParentId != nil {
+		task.ParentID = converter.FromID(req.GetParentId().GetValue())
+	}
+	if req.GetClearDue() {
+		task.DueAt = nil
+	} else if req.GetDueAt() != nil {
+		dueAt := req.GetDueAt().AsTime()
+		task.DueAt = &dueAt
+	}
+
+	if err := i.taskService.UpdateTask(ctx, task, model.UpdateOptions{Force: req.GetForce()}); err != nil {
+		return nil, err
+	}
+
+	return &desc.UpdateTaskResponse{
+		Task: converter.ToTaskFromService(task),
+	}, nil
+}
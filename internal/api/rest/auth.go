@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"net/http"
+)
+
+func (h *Handler) register(w http.ResponseWriter, r *http.Request) {
+	var req credentialsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	user, err := h.authService.Register(r.Context(), req.Login, req.Password)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, userResponse{ID: user.ID, Login: user.Login})
+}
+
+func (h *Handler) login(w http.ResponseWriter, r *http.Request) {
+	var req credentialsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	token, err := h.authService.Login(r.Context(), req.Login, req.Password)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, tokenResponse{Token: token})
+}
+
+func (h *Handler) logout(w http.ResponseWriter, r *http.Request) {
+	if err := h.authService.Logout(r.Context(), bearerToken(r)); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
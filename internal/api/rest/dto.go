@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"encoding/json"
+	"techno/internal/model"
+	"time"
+)
+
+type taskResponse struct {
+	ID          int        `json:"id"`
+	DisplayID   string     `json:"display_id"`
+	Project     string     `json:"project,omitempty"`
+	ParentID    *int       `json:"parent_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority"`
+	DueAt       *time.Time `json:"due_at"`
+	Overdue     bool       `json:"overdue"`
+	Waiting     bool       `json:"waiting"`
+	Tags        []string   `json:"tags"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+type createTaskRequest struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Priority    string     `json:"priority"`
+	DueAt       *time.Time `json:"due_at"`
+	Tags        []string   `json:"tags"`
+	Project     string     `json:"project"`
+	ParentID    *int       `json:"parent_id"`
+}
+
+// updateTaskRequest only changes the fields present in the body.
+type updateTaskRequest struct {
+	Title       *string      `json:"title"`
+	Description *string      `json:"description"`
+	Status      *string      `json:"status"`
+	Priority    *string      `json:"priority"`
+	DueAt       optionalTime `json:"due_at"`
+	Tags        *[]string    `json:"tags"`
+	// Project moves the task to another project, "" takes it out.
+	Project *string `json:"project"`
+	// ParentID moves the task under another task, null makes it top level.
+	ParentID optionalInt `json:"parent_id"`
+}
+
+// optionalTime tells an absent field apart from an explicit null, which
+// removes the value.
+type optionalTime struct {
+	Set   bool
+	Value *time.Time
+}
+
+func (o *optionalTime) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+
+	var t time.Time
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+	o.Value = &t
+	return nil
+}
+
+// optionalInt is optionalTime for ids.
+type optionalInt struct {
+	Set   bool
+	Value *int
+}
+
+func (o *optionalInt) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+
+	var v int
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	o.Value = &v
+	return nil
+}
+
+type credentialsRequest struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+type userResponse struct {
+	ID    int    `json:"id"`
+	Login string `json:"login"`
+}
+
+type tokenResponse struct {
+	Token string `json:"token"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func toTaskResponse(task *model.Task) taskResponse {
+	return taskResponse{
+		ID:          task.ID,
+		DisplayID:   task.DisplayID(),
+		Project:     task.ProjectKey,
+		ParentID:    task.ParentID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status.StringStatus(),
+		Priority:    task.Priority.StringPriority(),
+		DueAt:       task.DueAt,
+		Overdue:     task.IsOverdue(time.Now()),
+		Waiting:     task.IsWaiting(),
+		Tags:        task.Tags,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+		CompletedAt: task.CompletedAt,
+	}
+}
+
+func toTaskResponses(tasks []*model.Task) []taskResponse {
+	resp := make([]taskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		resp = append(resp, toTaskResponse(task))
+	}
+	return resp
+}
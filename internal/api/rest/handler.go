@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"net/http"
+	"techno/internal/config/logger"
+	"techno/internal/service"
+
+	"github.com/rs/zerolog"
+)
+
+type Handler struct {
+	taskService service.TaskService
+	authService service.AuthService
+	log         zerolog.Logger
+}
+
+func NewHandler(taskService service.TaskService, authService service.AuthService) *Handler {
+	return &Handler{
+		taskService: taskService,
+		authService: authService,
+		log:         logger.GetLogger("api.rest"),
+	}
+}
+
+func (h *Handler) Routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /auth/register", h.register)
+	mux.HandleFunc("POST /auth/login", h.login)
+	mux.Handle("POST /auth/logout", h.requireAuth(http.HandlerFunc(h.logout)))
+
+	mux.Handle("POST /tasks", h.requireAuth(http.HandlerFunc(h.createTask)))
+	mux.Handle("GET /tasks", h.requireAuth(http.HandlerFunc(h.listTasks)))
+	mux.Handle("GET /tasks/{id}", h.requireAuth(http.HandlerFunc(h.getTask)))
+	mux.Handle("PUT /tasks/{id}", h.requireAuth(http.HandlerFunc(h.updateTask)))
+	mux.Handle("PATCH /tasks/{id}", h.requireAuth(http.HandlerFunc(h.updateTask)))
+	mux.Handle("DELETE /tasks/{id}", h.requireAuth(http.HandlerFunc(h.deleteTask)))
+
+	return h.logRequests(mux)
+}
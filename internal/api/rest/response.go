@@ -0,0 +1,124 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"techno/internal/model"
+	"time"
+)
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.log.Error().Err(err).Msg("failed to encode response")
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, model.ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, model.ErrUnauthenticated), errors.Is(err, model.ErrInvalidCredentials):
+		status = http.StatusUnauthorized
+	case errors.Is(err, model.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, model.ErrAlreadyExists), errors.Is(err, model.ErrInvalidTransition), errors.Is(err, model.ErrHasSubtasks),
+		errors.Is(err, model.ErrConflict):
+		status = http.StatusConflict
+	}
+
+	if status == http.StatusInternalServerError {
+		h.log.Error().Err(err).Msg("request failed")
+	}
+
+	h.writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func decodeJSON(r *http.Request, dst any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("%w: malformed request body: %v", model.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+func pathID(r *http.Request) (int, error) {
+	raw := r.PathValue("id")
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: invalid task id %q", model.ErrInvalidInput, raw)
+	}
+	return id, nil
+}
+
+func parseTaskFilter(query url.Values) (model.TaskFilter, error) {
+	var filter model.TaskFilter
+
+	if raw := query.Get("status"); raw != "" {
+		status, err := model.ParseTaskStatus(raw)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = &status
+	}
+
+	var err error
+	if filter.Overdue, err = queryBool(query, "overdue"); err != nil {
+		return filter, err
+	}
+	if filter.DueBefore, err = parseQueryTime(query, "due_before"); err != nil {
+		return filter, err
+	}
+	if filter.DueAfter, err = parseQueryTime(query, "due_after"); err != nil {
+		return filter, err
+	}
+	filter.Tags = query["tag"]
+	switch raw := query.Get("tag_match"); raw {
+	case "", "any":
+	case "all":
+		filter.AllTags = true
+	default:
+		return filter, fmt.Errorf("%w: tag_match must be any or all", model.ErrInvalidInput)
+	}
+	filter.Project = query.Get("project")
+	if raw := query.Get("sort"); raw != "" {
+		if filter.Sort, err = model.ParseSort(raw); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, nil
+}
+
+func queryBool(query url.Values, key string) (bool, error) {
+	raw := query.Get(key)
+	if raw == "" {
+		return false, nil
+	}
+
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("%w: invalid %s flag %q", model.ErrInvalidInput, key, raw)
+	}
+	return v, nil
+}
+
+func parseQueryTime(query url.Values, key string) (*time.Time, error) {
+	raw := query.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be an RFC 3339 timestamp", model.ErrInvalidInput, key)
+	}
+	return &t, nil
+}
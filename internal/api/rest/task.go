@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"net/http"
+	"techno/internal/model"
+)
+
+func (h *Handler) createTask(w http.ResponseWriter, r *http.Request) {
+	var req createTaskRequest
+	if err := decodeJSON(r, &req); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	task := &model.Task{
+		Title:       req.Title,
+		Description: req.Description,
+		DueAt:       req.DueAt,
+		Tags:        req.Tags,
+		ProjectKey:  req.Project,
+		ParentID:    req.ParentID,
+	}
+	if req.Priority != "" {
+		priority, err := model.ParseTaskPriority(req.Priority)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+		task.Priority = priority
+	}
+	if err := h.taskService.CreateTask(r.Context(), task); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, toTaskResponse(task))
+}
+
+func (h *Handler) listTasks(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTaskFilter(r.URL.Query())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	tasks, err := h.taskService.List(r.Context(), filter)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, toTaskResponses(tasks))
+}
+
+func (h *Handler) getTask(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	task, err := h.taskService.GetByID(r.Context(), id)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, toTaskResponse(task))
+}
+
+func (h *Handler) updateTask(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	var req updateTaskRequest
+	if err := decodeJSON(r, &req); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	task, err := h.taskService.GetByID(r.Context(), id)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	if req.Title != nil {
+		task.Title = *req.Title
+	}
+	if req.Description != nil {
+		task.Description = *req.Description
+	}
+	if req.Status != nil {
+		status, err := model.ParseTaskStatus(*req.Status)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+		task.Status = status
+	}
+	if req.Priority != nil {
+		priority, err := model.ParseTaskPriority(*req.Priority)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+		task.Priority = priority
+	}
+	if req.Tags != nil {
+		task.Tags = *req.Tags
+	}
+	if req.Project != nil {
+		task.ProjectKey = *req.Project
+	}
+	if req.DueAt.Set {
+		task.DueAt = req.DueAt.Value
+	}
+	if req.ParentID.Set {
+		task.ParentID = req.ParentID.Value
+	}
+
+	var opts model.UpdateOptions
+	if opts.Force, err = queryBool(r.URL.Query(), "force"); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if err := h.taskService.UpdateTask(r.Context(), task, opts); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, toTaskResponse(task))
+}
+
+func (h *Handler) deleteTask(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	policy := model.ChildrenRefuse
+	if raw := r.URL.Query().Get("children"); raw != "" {
+		if policy, err = model.ParseChildPolicy(raw); err != nil {
+			h.writeError(w, err)
+			return
+		}
+	}
+
+	if err := h.taskService.DeleteTask(r.Context(), id, policy); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
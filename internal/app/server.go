@@ -0,0 +1,147 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"techno/internal/api/grpc/interceptor"
+	"techno/internal/config"
+	authDesc "techno/pkg/auth_v1"
+	taskDesc "techno/pkg/task_v1"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+type ServerApp struct {
+	serviceProvider *serviceProvider
+	httpServer      *http.Server
+	grpcServer      *grpc.Server
+}
+
+func NewServerApp(ctx context.Context) (*ServerApp, error) {
+	a := &ServerApp{}
+	err := a.initDeps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Run serves the REST and gRPC APIs until one of them fails or Stop is called.
+func (a *ServerApp) Run() error {
+	errCh := make(chan error, 2)
+
+	go func() {
+		errCh <- a.runHTTPServer()
+	}()
+	go func() {
+		errCh <- a.runGRPCServer()
+	}()
+
+	return <-errCh
+}
+
+func (a *ServerApp) initDeps(ctx context.Context) error {
+	inits := []func(context.Context) error{
+		a.initConfig,
+		a.initServiceProvider,
+		a.initLogger,
+		a.initSchema,
+		a.initHTTPServer,
+		a.initGRPCServer,
+	}
+
+	for _, f := range inits {
+		err := f(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ServerApp) initConfig(_ context.Context) error {
+	return config.Load(".env")
+}
+
+func (a *ServerApp) initServiceProvider(_ context.Context) error {
+	a.serviceProvider = newServiceProvider()
+	return nil
+}
+
+func (a *ServerApp) initSchema(ctx context.Context) error {
+	return a.serviceProvider.CheckSchema(ctx)
+}
+
+func (a *ServerApp) initLogger(_ context.Context) error {
+	loggerCfg := a.serviceProvider.LoggerConfig()
+	if err := loggerCfg.Initialize(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *ServerApp) initHTTPServer(ctx context.Context) error {
+	a.httpServer = &http.Server{
+		Addr:              a.serviceProvider.HTTPConfig().Address(),
+		Handler:           a.serviceProvider.RestHandler(ctx).Routes(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return nil
+}
+
+func (a *ServerApp) initGRPCServer(ctx context.Context) error {
+	authInterceptor := interceptor.NewAuthInterceptor(a.serviceProvider.AuthService(ctx))
+
+	a.grpcServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptor.ErrorsUnary, authInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(interceptor.ErrorsStream, authInterceptor.Stream()),
+	)
+
+	reflection.Register(a.grpcServer)
+
+	taskDesc.RegisterTaskV1Server(a.grpcServer, a.serviceProvider.TaskImpl(ctx))
+	authDesc.RegisterAuthV1Server(a.grpcServer, a.serviceProvider.AuthImpl(ctx))
+	return nil
+}
+
+func (a *ServerApp) runHTTPServer() error {
+	log.Info().Str("address", a.httpServer.Addr).Msg("Starting HTTP server")
+
+	err := a.httpServer.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (a *ServerApp) runGRPCServer() error {
+	address := a.serviceProvider.GRPCConfig().Address()
+	log.Info().Str("address", address).Msg("Starting gRPC server")
+
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return a.grpcServer.Serve(lis)
+}
+
+func (a *ServerApp) Stop(ctx context.Context) error {
+	if a.grpcServer != nil {
+		a.grpcServer.GracefulStop()
+	}
+
+	if a.httpServer != nil {
+		if err := a.httpServer.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+
+	a.serviceProvider.Close()
+	return nil
+}
@@ -2,32 +2,101 @@ package app
 
 import (
 	"context"
+	"database/sql"
 	"log"
+	authAPI "techno/internal/api/grpc/auth"
+	taskAPI "techno/internal/api/grpc/task"
+	"techno/internal/api/rest"
+	"techno/internal/auth"
 	"techno/internal/cli"
+	"techno/internal/config/cleaner"
 	"techno/internal/config/db"
 	"techno/internal/config/logger"
+	"techno/internal/config/server"
+	"techno/internal/config/storage"
+	"techno/internal/config/urgency"
 	infra "techno/internal/db"
+	"techno/internal/migrator"
 	"techno/internal/repository"
+	dependencyRepo "techno/internal/repository/dependency"
+	journalRepo "techno/internal/repository/journal"
+	"techno/internal/repository/memory"
+	projectRepo "techno/internal/repository/project"
+	recurrenceRepo "techno/internal/repository/recurrence"
+	sessionRepo "techno/internal/repository/session"
+	sqliteRepo "techno/internal/repository/sqlite"
+	tagRepo "techno/internal/repository/tag"
 	taskRepo "techno/internal/repository/task"
+	userRepo "techno/internal/repository/user"
 	"techno/internal/service"
+	archiveService "techno/internal/service/archive"
+	authService "techno/internal/service/auth"
+	dependencyService "techno/internal/service/dependency"
+	historyService "techno/internal/service/history"
+	journalService "techno/internal/service/journal"
+	projectService "techno/internal/service/project"
+	recurrenceService "techno/internal/service/recurrence"
+	tagService "techno/internal/service/tag"
 	taskService "techno/internal/service/task"
+	trashService "techno/internal/service/trash"
 	"techno/internal/timer"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/spf13/cobra"
 )
 
 type serviceProvider struct {
-	dbConfig     db.DBConfig
-	loggerConfig logger.LoggerConfig
-	db           *pgxpool.Pool
+	dbConfig      db.DBConfig
+	loggerConfig  logger.LoggerConfig
+	httpConfig    server.HTTPConfig
+	grpcConfig    server.GRPCConfig
+	storageConfig storage.StorageConfig
+	urgencyConfig urgency.UrgencyConfig
+	cleanerConfig cleaner.CleanerConfig
+	db            *pgxpool.Pool
+	sqliteDB      *sql.DB
+	memoryStorage *memory.Storage
+	migrator      *migrator.Migrator
 
-	taskRepository repository.TaskRepository
-	taskService    service.TaskService
-	taskCleaner    *timer.TaskCleaner
-	taskCommands   *cli.TaskCommands
-	rootCmd        *cobra.Command
+	taskRepository       repository.TaskRepository
+	tagRepository        repository.TagRepository
+	projectRepository    repository.ProjectRepository
+	dependencyRepository repository.DependencyRepository
+	recurrenceRepository repository.RecurrenceRepository
+	archiveRepository    repository.ArchiveRepository
+	trashRepository      repository.TrashRepository
+	journalRepository    repository.JournalRepository
+	eventRepository      repository.EventRepository
+	userRepository       repository.UserRepository
+	sessionRepository    repository.SessionRepository
+	taskService          service.TaskService
+	tagService           service.TagService
+	projectService       service.ProjectService
+	dependencyService    service.DependencyService
+	recurrenceService    service.RecurrenceService
+	archiveService       service.ArchiveService
+	trashService         service.TrashService
+	journalService       service.JournalService
+	historyService       service.HistoryService
+	authService          service.AuthService
+	tokenStore           *auth.TokenStore
+	taskCleaner          *timer.TaskCleaner
+	recurrenceScheduler  *timer.RecurrenceScheduler
+	taskCommands         *cli.TaskCommands
+	tagCommands          *cli.TagCommands
+	projectCommands      *cli.ProjectCommands
+	recurrenceCommands   *cli.RecurrenceCommands
+	archiveCommands      *cli.ArchiveCommands
+	trashCommands        *cli.TrashCommands
+	journalCommands      *cli.JournalCommands
+	authCommands         *cli.AuthCommands
+	migrateCommands      *cli.MigrateCommands
+	rootCmd              *cobra.Command
+	restHandler          *rest.Handler
+	taskImpl             *taskAPI.Implementation
+	authImpl             *authAPI.Implementation
 }
 
 func newServiceProvider() *serviceProvider {
@@ -38,7 +107,7 @@ func (s *serviceProvider) LoggerConfig() logger.LoggerConfig {
 	if s.loggerConfig == nil {
 		cfg, err := logger.NewLoggerConfig()
 		if err != nil {
-			log.Fatal("failed to get log config: %s", err.Error())
+			log.Fatalf("failed to get log config: %s", err.Error())
 		}
 		s.loggerConfig = cfg
 	}
@@ -56,6 +125,61 @@ func (s *serviceProvider) DBConfig() db.DBConfig {
 	return s.dbConfig
 }
 
+func (s *serviceProvider) HTTPConfig() server.HTTPConfig {
+	if s.httpConfig == nil {
+		cfg, err := server.NewHTTPConfig()
+		if err != nil {
+			log.Fatalf("failed to get http config: %s", err.Error())
+		}
+		s.httpConfig = cfg
+	}
+	return s.httpConfig
+}
+
+func (s *serviceProvider) GRPCConfig() server.GRPCConfig {
+	if s.grpcConfig == nil {
+		cfg, err := server.NewGRPCConfig()
+		if err != nil {
+			log.Fatalf("failed to get grpc config: %s", err.Error())
+		}
+		s.grpcConfig = cfg
+	}
+	return s.grpcConfig
+}
+
+func (s *serviceProvider) StorageConfig() storage.StorageConfig {
+	if s.storageConfig == nil {
+		cfg, err := storage.NewStorageConfig()
+		if err != nil {
+			log.Fatalf("failed to get storage config: %s", err.Error())
+		}
+		s.storageConfig = cfg
+	}
+	return s.storageConfig
+}
+
+func (s *serviceProvider) UrgencyConfig() urgency.UrgencyConfig {
+	if s.urgencyConfig == nil {
+		cfg, err := urgency.NewUrgencyConfig()
+		if err != nil {
+			log.Fatalf("failed to get urgency config: %s", err.Error())
+		}
+		s.urgencyConfig = cfg
+	}
+	return s.urgencyConfig
+}
+
+func (s *serviceProvider) CleanerConfig() cleaner.CleanerConfig {
+	if s.cleanerConfig == nil {
+		cfg, err := cleaner.NewCleanerConfig()
+		if err != nil {
+			log.Fatalf("failed to get cleaner config: %s", err.Error())
+		}
+		s.cleanerConfig = cfg
+	}
+	return s.cleanerConfig
+}
+
 func (s *serviceProvider) DB(ctx context.Context) *pgxpool.Pool {
 	if s.db == nil {
 		pool, err := infra.InitDB(s.DBConfig())
@@ -67,46 +191,424 @@ func (s *serviceProvider) DB(ctx context.Context) *pgxpool.Pool {
 	return s.db
 }
 
+func (s *serviceProvider) SQLiteDB() *sql.DB {
+	if s.sqliteDB == nil {
+		conn, err := infra.InitSQLite(s.StorageConfig().SQLitePath())
+		if err != nil {
+			log.Fatalf("failed to open sqlite database: %s", err.Error())
+		}
+		s.sqliteDB = conn
+	}
+	return s.sqliteDB
+}
+
+// Migrator returns nil for the memory storage, which has no schema.
+func (s *serviceProvider) Migrator(ctx context.Context) *migrator.Migrator {
+	if s.migrator == nil {
+		var conn *sql.DB
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			return nil
+		case storage.DriverSQLite:
+			conn = s.SQLiteDB()
+		default:
+			conn = stdlib.OpenDBFromPool(s.DB(ctx))
+		}
+
+		m, err := migrator.New(s.StorageConfig().Driver(), conn)
+		if err != nil {
+			log.Fatalf("failed to init migrator: %s", err.Error())
+		}
+		s.migrator = m
+	}
+	return s.migrator
+}
+
+// CheckSchema makes sure the database was migrated to the version this
+// binary expects before any repository touches it.
+func (s *serviceProvider) CheckSchema(ctx context.Context) error {
+	m := s.Migrator(ctx)
+	if m == nil {
+		return nil
+	}
+	return m.CheckVersion(ctx)
+}
+
+func (s *serviceProvider) MemoryStorage() *memory.Storage {
+	if s.memoryStorage == nil {
+		s.memoryStorage = memory.NewStorage()
+	}
+	return s.memoryStorage
+}
+
 func (s *serviceProvider) TaskRepository(ctx context.Context) repository.TaskRepository {
 	if s.taskRepository == nil {
-		s.taskRepository = taskRepo.NewRepository(s.DB(ctx))
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			s.taskRepository = memory.NewTaskRepository(s.MemoryStorage())
+		case storage.DriverSQLite:
+			s.taskRepository = sqliteRepo.NewTaskRepository(s.SQLiteDB())
+		default:
+			s.taskRepository = taskRepo.NewRepository(s.DB(ctx))
+		}
 	}
 	return s.taskRepository
 }
 
+func (s *serviceProvider) TagRepository(ctx context.Context) repository.TagRepository {
+	if s.tagRepository == nil {
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			s.tagRepository = memory.NewTagRepository(s.MemoryStorage())
+		case storage.DriverSQLite:
+			s.tagRepository = sqliteRepo.NewTagRepository(s.SQLiteDB())
+		default:
+			s.tagRepository = tagRepo.NewRepository(s.DB(ctx))
+		}
+	}
+	return s.tagRepository
+}
+
+func (s *serviceProvider) ProjectRepository(ctx context.Context) repository.ProjectRepository {
+	if s.projectRepository == nil {
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			s.projectRepository = memory.NewProjectRepository(s.MemoryStorage())
+		case storage.DriverSQLite:
+			s.projectRepository = sqliteRepo.NewProjectRepository(s.SQLiteDB())
+		default:
+			s.projectRepository = projectRepo.NewRepository(s.DB(ctx))
+		}
+	}
+	return s.projectRepository
+}
+
+func (s *serviceProvider) DependencyRepository(ctx context.Context) repository.DependencyRepository {
+	if s.dependencyRepository == nil {
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			s.dependencyRepository = memory.NewDependencyRepository(s.MemoryStorage())
+		case storage.DriverSQLite:
+			s.dependencyRepository = sqliteRepo.NewDependencyRepository(s.SQLiteDB())
+		default:
+			s.dependencyRepository = dependencyRepo.NewRepository(s.DB(ctx))
+		}
+	}
+	return s.dependencyRepository
+}
+
+func (s *serviceProvider) RecurrenceRepository(ctx context.Context) repository.RecurrenceRepository {
+	if s.recurrenceRepository == nil {
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			s.recurrenceRepository = memory.NewRecurrenceRepository(s.MemoryStorage())
+		case storage.DriverSQLite:
+			s.recurrenceRepository = sqliteRepo.NewRecurrenceRepository(s.SQLiteDB())
+		default:
+			s.recurrenceRepository = recurrenceRepo.NewRepository(s.DB(ctx))
+		}
+	}
+	return s.recurrenceRepository
+}
+
+func (s *serviceProvider) ArchiveRepository(ctx context.Context) repository.ArchiveRepository {
+	if s.archiveRepository == nil {
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			s.archiveRepository = memory.NewArchiveRepository(s.MemoryStorage())
+		case storage.DriverSQLite:
+			s.archiveRepository = sqliteRepo.NewArchiveRepository(s.SQLiteDB())
+		default:
+			s.archiveRepository = taskRepo.NewArchiveRepository(s.DB(ctx))
+		}
+	}
+	return s.archiveRepository
+}
+
+func (s *serviceProvider) TrashRepository(ctx context.Context) repository.TrashRepository {
+	if s.trashRepository == nil {
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			s.trashRepository = memory.NewTrashRepository(s.MemoryStorage())
+		case storage.DriverSQLite:
+			s.trashRepository = sqliteRepo.NewTrashRepository(s.SQLiteDB())
+		default:
+			s.trashRepository = taskRepo.NewTrashRepository(s.DB(ctx))
+		}
+	}
+	return s.trashRepository
+}
+
+func (s *serviceProvider) JournalRepository(ctx context.Context) repository.JournalRepository {
+	if s.journalRepository == nil {
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			s.journalRepository = memory.NewJournalRepository(s.MemoryStorage())
+		case storage.DriverSQLite:
+			s.journalRepository = sqliteRepo.NewJournalRepository(s.SQLiteDB())
+		default:
+			s.journalRepository = journalRepo.NewRepository(s.DB(ctx))
+		}
+	}
+	return s.journalRepository
+}
+
+func (s *serviceProvider) EventRepository(ctx context.Context) repository.EventRepository {
+	if s.eventRepository == nil {
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			s.eventRepository = memory.NewEventRepository(s.MemoryStorage())
+		case storage.DriverSQLite:
+			s.eventRepository = sqliteRepo.NewEventRepository(s.SQLiteDB())
+		default:
+			s.eventRepository = taskRepo.NewEventRepository(s.DB(ctx))
+		}
+	}
+	return s.eventRepository
+}
+
+func (s *serviceProvider) UserRepository(ctx context.Context) repository.UserRepository {
+	if s.userRepository == nil {
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			s.userRepository = memory.NewUserRepository(s.MemoryStorage())
+		case storage.DriverSQLite:
+			s.userRepository = sqliteRepo.NewUserRepository(s.SQLiteDB())
+		default:
+			s.userRepository = userRepo.NewRepository(s.DB(ctx))
+		}
+	}
+	return s.userRepository
+}
+
+func (s *serviceProvider) SessionRepository(ctx context.Context) repository.SessionRepository {
+	if s.sessionRepository == nil {
+		switch s.StorageConfig().Driver() {
+		case storage.DriverMemory:
+			s.sessionRepository = memory.NewSessionRepository(s.MemoryStorage())
+		case storage.DriverSQLite:
+			s.sessionRepository = sqliteRepo.NewSessionRepository(s.SQLiteDB())
+		default:
+			s.sessionRepository = sessionRepo.NewRepository(s.DB(ctx))
+		}
+	}
+	return s.sessionRepository
+}
+
 func (s *serviceProvider) TaskService(ctx context.Context) service.TaskService {
 	if s.taskService == nil {
-		s.taskService = taskService.NewService(s.TaskRepository(ctx))
+		s.taskService = taskService.NewService(s.TaskRepository(ctx), s.ProjectRepository(ctx), s.UrgencyConfig().Weights())
 	}
 	return s.taskService
 }
 
+func (s *serviceProvider) TagService(ctx context.Context) service.TagService {
+	if s.tagService == nil {
+		s.tagService = tagService.NewService(s.TagRepository(ctx))
+	}
+	return s.tagService
+}
+
+func (s *serviceProvider) ProjectService(ctx context.Context) service.ProjectService {
+	if s.projectService == nil {
+		s.projectService = projectService.NewService(s.ProjectRepository(ctx))
+	}
+	return s.projectService
+}
+
+func (s *serviceProvider) DependencyService(ctx context.Context) service.DependencyService {
+	if s.dependencyService == nil {
+		s.dependencyService = dependencyService.NewService(s.DependencyRepository(ctx), s.TaskRepository(ctx))
+	}
+	return s.dependencyService
+}
+
+func (s *serviceProvider) RecurrenceService(ctx context.Context) service.RecurrenceService {
+	if s.recurrenceService == nil {
+		s.recurrenceService = recurrenceService.NewService(s.RecurrenceRepository(ctx), s.TaskRepository(ctx), s.ProjectRepository(ctx))
+	}
+	return s.recurrenceService
+}
+
+func (s *serviceProvider) ArchiveService(ctx context.Context) service.ArchiveService {
+	if s.archiveService == nil {
+		s.archiveService = archiveService.NewService(s.ArchiveRepository(ctx), s.TaskRepository(ctx))
+	}
+	return s.archiveService
+}
+
+func (s *serviceProvider) TrashService(ctx context.Context) service.TrashService {
+	if s.trashService == nil {
+		s.trashService = trashService.NewService(s.TrashRepository(ctx))
+	}
+	return s.trashService
+}
+
+func (s *serviceProvider) JournalService(ctx context.Context) service.JournalService {
+	if s.journalService == nil {
+		s.journalService = journalService.NewService(s.JournalRepository(ctx), s.TaskRepository(ctx), s.TrashRepository(ctx))
+	}
+	return s.journalService
+}
+
+func (s *serviceProvider) HistoryService(ctx context.Context) service.HistoryService {
+	if s.historyService == nil {
+		s.historyService = historyService.NewService(s.EventRepository(ctx), s.TaskRepository(ctx), s.ProjectRepository(ctx))
+	}
+	return s.historyService
+}
+
+func (s *serviceProvider) AuthService(ctx context.Context) service.AuthService {
+	if s.authService == nil {
+		s.authService = authService.NewService(s.UserRepository(ctx), s.SessionRepository(ctx))
+	}
+	return s.authService
+}
+
+func (s *serviceProvider) TokenStore() *auth.TokenStore {
+	if s.tokenStore == nil {
+		store, err := auth.NewTokenStore()
+		if err != nil {
+			log.Fatalf("failed to init token store: %s", err.Error())
+		}
+		s.tokenStore = store
+	}
+	return s.tokenStore
+}
+
 func (s *serviceProvider) TaskCommands(ctx context.Context) *cli.TaskCommands {
 	if s.taskCommands == nil {
-		s.taskCommands = cli.NewTaskCommands(s.TaskService(ctx))
+		s.taskCommands = cli.NewTaskCommands(s.TaskService(ctx), s.DependencyService(ctx), s.HistoryService(ctx), s.LoggerConfig().TimeLocation())
 	}
 	return s.taskCommands
 }
 
+func (s *serviceProvider) TagCommands(ctx context.Context) *cli.TagCommands {
+	if s.tagCommands == nil {
+		s.tagCommands = cli.NewTagCommands(s.TagService(ctx))
+	}
+	return s.tagCommands
+}
+
+func (s *serviceProvider) ProjectCommands(ctx context.Context) *cli.ProjectCommands {
+	if s.projectCommands == nil {
+		s.projectCommands = cli.NewProjectCommands(s.ProjectService(ctx))
+	}
+	return s.projectCommands
+}
+
+func (s *serviceProvider) RecurrenceCommands(ctx context.Context) *cli.RecurrenceCommands {
+	if s.recurrenceCommands == nil {
+		s.recurrenceCommands = cli.NewRecurrenceCommands(s.RecurrenceService(ctx), s.LoggerConfig().TimeLocation())
+	}
+	return s.recurrenceCommands
+}
+
+func (s *serviceProvider) ArchiveCommands(ctx context.Context) *cli.ArchiveCommands {
+	if s.archiveCommands == nil {
+		s.archiveCommands = cli.NewArchiveCommands(s.ArchiveService(ctx), s.LoggerConfig().TimeLocation())
+	}
+	return s.archiveCommands
+}
+
+func (s *serviceProvider) TrashCommands(ctx context.Context) *cli.TrashCommands {
+	if s.trashCommands == nil {
+		s.trashCommands = cli.NewTrashCommands(s.TrashService(ctx), s.LoggerConfig().TimeLocation())
+	}
+	return s.trashCommands
+}
+
+func (s *serviceProvider) JournalCommands(ctx context.Context) *cli.JournalCommands {
+	if s.journalCommands == nil {
+		s.journalCommands = cli.NewJournalCommands(s.JournalService(ctx))
+	}
+	return s.journalCommands
+}
+
+func (s *serviceProvider) AuthCommands(ctx context.Context) *cli.AuthCommands {
+	if s.authCommands == nil {
+		s.authCommands = cli.NewAuthCommands(s.AuthService(ctx), s.TokenStore())
+	}
+	return s.authCommands
+}
+
+func (s *serviceProvider) MigrateCommands(ctx context.Context) *cli.MigrateCommands {
+	if s.migrateCommands == nil {
+		s.migrateCommands = cli.NewMigrateCommands(s.Migrator(ctx))
+	}
+	return s.migrateCommands
+}
+
 func (s *serviceProvider) RootCmd(ctx context.Context) *cobra.Command {
 	if s.rootCmd == nil {
-		s.rootCmd = cli.NewRootCommand()
+		s.rootCmd = cli.NewRootCommand(
+			s.MigrateCommands(ctx).CheckSchema,
+			s.AuthCommands(ctx).Authenticate,
+		)
 
+		s.MigrateCommands(ctx).RegisterCommands(s.rootCmd)
+		s.AuthCommands(ctx).RegisterCommands(s.rootCmd)
 		s.TaskCommands(ctx).RegisterCommands(s.rootCmd)
+		s.TagCommands(ctx).RegisterCommands(s.rootCmd)
+		s.ProjectCommands(ctx).RegisterCommands(s.rootCmd)
+		s.RecurrenceCommands(ctx).RegisterCommands(s.rootCmd)
+		s.ArchiveCommands(ctx).RegisterCommands(s.rootCmd)
+		s.TrashCommands(ctx).RegisterCommands(s.rootCmd)
+		s.JournalCommands(ctx).RegisterCommands(s.rootCmd)
 	}
 	return s.rootCmd
 }
 
+func (s *serviceProvider) RestHandler(ctx context.Context) *rest.Handler {
+	if s.restHandler == nil {
+		s.restHandler = rest.NewHandler(s.TaskService(ctx), s.AuthService(ctx))
+	}
+	return s.restHandler
+}
+
+func (s *serviceProvider) TaskImpl(ctx context.Context) *taskAPI.Implementation {
+	if s.taskImpl == nil {
+		s.taskImpl = taskAPI.NewImplementation(s.TaskService(ctx))
+	}
+	return s.taskImpl
+}
+
+func (s *serviceProvider) AuthImpl(ctx context.Context) *authAPI.Implementation {
+	if s.authImpl == nil {
+		s.authImpl = authAPI.NewImplementation(s.AuthService(ctx))
+	}
+	return s.authImpl
+}
+
 func (s *serviceProvider) TaskCleaner(ctx context.Context) *timer.TaskCleaner {
 	if s.taskCleaner == nil {
-		s.taskCleaner = timer.NewTaskCleaner(s.TaskService(ctx), 30*time.Second) //5*time.Minute)
+		s.taskCleaner = timer.NewTaskCleaner(
+			s.TaskService(ctx),
+			s.ArchiveService(ctx),
+			s.TrashService(ctx),
+			s.CleanerConfig().Interval(),
+			s.CleanerConfig().Mode(),
+			s.CleanerConfig().Policy(),
+			s.CleanerConfig().TrashRetention(),
+		)
 	}
 	return s.taskCleaner
 }
 
+func (s *serviceProvider) RecurrenceScheduler(ctx context.Context) *timer.RecurrenceScheduler {
+	if s.recurrenceScheduler == nil {
+		s.recurrenceScheduler = timer.NewRecurrenceScheduler(s.RecurrenceService(ctx), time.Minute)
+	}
+	return s.recurrenceScheduler
+}
+
 func (s *serviceProvider) Close() {
 	if s.db != nil {
 		s.db.Close()
 		log.Println("Database connection closed")
 	}
+	if s.sqliteDB != nil {
+		s.sqliteDB.Close()
+		log.Println("SQLite database closed")
+	}
 }
@@ -31,6 +31,7 @@ func (a *WorkerApp) initDeps(ctx context.Context) error {
 		a.initConfig,
 		a.initServiceProvider,
 		a.initLogger,
+		a.initSchema,
 		a.initWorker,
 	}
 
@@ -56,6 +57,8 @@ func (a *WorkerApp) initWorker(ctx context.Context) error {
 	a.cleanerCtx, a.cleanerCancel = context.WithCancel(context.Background())
 	cleaner := a.serviceProvider.TaskCleaner(a.cleanerCtx)
 	go cleaner.Start(a.cleanerCtx)
+	scheduler := a.serviceProvider.RecurrenceScheduler(a.cleanerCtx)
+	go scheduler.Start(a.cleanerCtx)
 	return nil
 }
 
@@ -75,6 +78,10 @@ func (a *WorkerApp) runWorker() error {
 	}
 }
 
+func (a *WorkerApp) initSchema(ctx context.Context) error {
+	return a.serviceProvider.CheckSchema(ctx)
+}
+
 func (a *WorkerApp) initLogger(_ context.Context) error {
 	loggerCfg := a.serviceProvider.LoggerConfig()
 	if err := loggerCfg.Initialize(); err != nil {
@@ -91,6 +98,9 @@ func (a *WorkerApp) Stop(ctx context.Context) error {
 	if a.serviceProvider.taskCleaner != nil {
 		a.serviceProvider.taskCleaner.Stop()
 	}
+	if a.serviceProvider.recurrenceScheduler != nil {
+		a.serviceProvider.recurrenceScheduler.Stop()
+	}
 
 	a.serviceProvider.Close()
 	return nil
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"techno/internal/model"
+)
+
+type principalKey struct{}
+
+// Principal is the identity a request is performed on behalf of.
+type Principal struct {
+	UserID int
+	Login  string
+	// Timezone is the user's preferred IANA zone, empty when not set.
+	Timezone string
+	system   bool
+}
+
+// System returns the principal used by background jobs. It is not bound to
+// any user and is allowed to access every user's data.
+func System() Principal {
+	return Principal{Login: "system", system: true}
+}
+
+func FromUser(user *model.User) Principal {
+	return Principal{
+		UserID:   user.ID,
+		Login:    user.Login,
+		Timezone: user.Timezone,
+	}
+}
+
+func (p Principal) IsSystem() bool {
+	return p.system
+}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
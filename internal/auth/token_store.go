@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	configDirName    = "taskmanager"
+	sessionFileName  = "session"
+	sessionFilePerms = 0600
+)
+
+// TokenStore keeps the CLI session token in the user's config directory.
+type TokenStore struct {
+	path string
+}
+
+func NewTokenStore() (*TokenStore, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate config directory: %w", err)
+	}
+
+	return &TokenStore{
+		path: filepath.Join(dir, configDirName, sessionFileName),
+	}, nil
+}
+
+func (s *TokenStore) Path() string {
+	return s.path
+}
+
+func (s *TokenStore) Load() (string, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read session file: %w", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (s *TokenStore) Save(token string) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, []byte(token+"\n"), sessionFilePerms); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TokenStore) Clear() error {
+	err := os.Remove(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove session file: %w", err)
+	}
+
+	return nil
+}
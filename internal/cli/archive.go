@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"techno/internal/model"
+	"techno/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+type ArchiveCommands struct {
+	archiveService service.ArchiveService
+	timezone       string
+}
+
+func NewArchiveCommands(archiveService service.ArchiveService, timezone string) *ArchiveCommands {
+	return &ArchiveCommands{
+		archiveService: archiveService,
+		timezone:       timezone,
+	}
+}
+
+func (ac *ArchiveCommands) RegisterCommands(rootCmd *cobra.Command) {
+	archiveCmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Browse archived tasks",
+		Long: "Browse the tasks the worker moved to the archive after their retention ran out. " +
+			"Archived tasks keep their id and every field and can be restored",
+	}
+
+	archiveCmd.AddCommand(ac.listCmd())
+	archiveCmd.AddCommand(ac.searchCmd())
+	archiveCmd.AddCommand(ac.restoreCmd())
+
+	rootCmd.AddCommand(archiveCmd)
+}
+
+func (ac *ArchiveCommands) listCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List archived tasks, recently archived first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tasks, err := ac.archiveService.List(cmd.Context())
+			if err != nil {
+				return err
+			}
+			ac.printTasks(cmd, tasks)
+			return nil
+		},
+	}
+}
+
+func (ac *ArchiveCommands) searchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "search [text]",
+		Short:   "Find archived tasks by title or description",
+		Long:    "Find archived tasks with the text in the title or the description, ignoring case",
+		Example: `  taskmanager archive search "quarterly report"`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tasks, err := ac.archiveService.Search(cmd.Context(), strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+			ac.printTasks(cmd, tasks)
+			return nil
+		},
+	}
+}
+
+func (ac *ArchiveCommands) restoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore [id]",
+		Short: "Move an archived task back to the task list",
+		Long: "Move an archived task back to the task list with the id it had. " +
+			"A parent or a recurring task that no longer exists is dropped",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("%w: invalid task id %q", model.ErrInvalidInput, args[0])
+			}
+
+			task, err := ac.archiveService.Restore(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Task %s restored: %s [%s]\n", task.DisplayID(), task.Title, task.Status.StringStatus())
+			return nil
+		},
+	}
+}
+
+func (ac *ArchiveCommands) printTasks(cmd *cobra.Command, tasks []*model.ArchivedTask) {
+	if len(tasks) == 0 {
+		fmt.Println("No archived tasks found")
+		return
+	}
+
+	loc := location(cmd.Context(), ac.timezone)
+	fmt.Printf("\n%-5s %-10s %-40s %-12s %-16s %-16s\n", "ID", "Task", "Title", "Status", "Completed", "Archived")
+	for _, task := range tasks {
+		fmt.Printf("%-5d %-10s %-40s %-12s %-16s %-16s\n",
+			task.ID,
+			task.DisplayID(),
+			truncate(task.Title, 40),
+			task.Status.StringStatus(),
+			formatDue(task.CompletedAt, loc),
+			task.ArchivedAt.In(loc).Format(dateTimeLayout),
+		)
+	}
+	fmt.Printf("\nTotal: %d archived task(s)\n\n", len(tasks))
+}
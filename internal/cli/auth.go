@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"techno/internal/auth"
+	"techno/internal/model"
+	"techno/internal/service"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// skipAuthAnnotation marks commands that can run without a logged in user.
+const skipAuthAnnotation = "skip-auth"
+
+type AuthCommands struct {
+	authService service.AuthService
+	tokens      *auth.TokenStore
+}
+
+func NewAuthCommands(authService service.AuthService, tokens *auth.TokenStore) *AuthCommands {
+	return &AuthCommands{
+		authService: authService,
+		tokens:      tokens,
+	}
+}
+
+func (ac *AuthCommands) RegisterCommands(rootCmd *cobra.Command) {
+	rootCmd.AddCommand(ac.registerCmd())
+	rootCmd.AddCommand(ac.loginCmd())
+	rootCmd.AddCommand(ac.logoutCmd())
+	rootCmd.AddCommand(ac.timezoneCmd())
+}
+
+// Authenticate is a pre-run hook that puts the logged in user into the
+// command context.
+func (ac *AuthCommands) Authenticate(cmd *cobra.Command, args []string) error {
+	if !requiresAuth(cmd) {
+		return nil
+	}
+
+	token, err := ac.tokens.Load()
+	if err != nil {
+		return err
+	}
+
+	user, err := ac.authService.Authenticate(cmd.Context(), token)
+	if err != nil {
+		if errors.Is(err, model.ErrUnauthenticated) {
+			return fmt.Errorf("%w, run `taskmanager login` first", err)
+		}
+		return err
+	}
+
+	cmd.SetContext(auth.WithPrincipal(cmd.Context(), auth.FromUser(user)))
+	return nil
+}
+
+func (ac *AuthCommands) registerCmd() *cobra.Command {
+	var login, password string
+
+	cmd := &cobra.Command{
+		Use:         "register",
+		Short:       "Create a new user account",
+		Example:     `  taskmanager register -u alice`,
+		Annotations: map[string]string{skipAuthAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if password == "" {
+				var err error
+				password, err = readPassword("Password: ")
+				if err != nil {
+					return err
+				}
+				confirm, err := readPassword("Repeat password: ")
+				if err != nil {
+					return err
+				}
+				if password != confirm {
+					return fmt.Errorf("passwords do not match")
+				}
+			}
+
+			user, err := ac.authService.Register(cmd.Context(), login, password)
+			if err != nil {
+				return fmt.Errorf("failed to register: %w", err)
+			}
+
+			fmt.Printf("User %s registered successfull, run `taskmanager login -u %s` to start\n", user.Login, user.Login)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&login, "user", "u", "", "Login (required)")
+	cmd.Flags().StringVarP(&password, "password", "p", "", "Password (prompted when omitted)")
+	cmd.MarkFlagRequired("user")
+
+	return cmd
+}
+
+func (ac *AuthCommands) loginCmd() *cobra.Command {
+	var login, password string
+
+	cmd := &cobra.Command{
+		Use:         "login",
+		Short:       "Log in and store the session token",
+		Example:     `  taskmanager login -u alice`,
+		Annotations: map[string]string{skipAuthAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if password == "" {
+				var err error
+				password, err = readPassword("Password: ")
+				if err != nil {
+					return err
+				}
+			}
+
+			token, err := ac.authService.Login(cmd.Context(), login, password)
+			if err != nil {
+				return fmt.Errorf("failed to login: %w", err)
+			}
+
+			if err := ac.tokens.Save(token); err != nil {
+				return err
+			}
+
+			fmt.Printf("Logged in as %s\n", strings.TrimSpace(login))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&login, "user", "u", "", "Login (required)")
+	cmd.Flags().StringVarP(&password, "password", "p", "", "Password (prompted when omitted)")
+	cmd.MarkFlagRequired("user")
+
+	return cmd
+}
+
+func (ac *AuthCommands) logoutCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:         "logout",
+		Short:       "Log out and remove the stored session token",
+		Annotations: map[string]string{skipAuthAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, err := ac.tokens.Load()
+			if err != nil {
+				return err
+			}
+
+			if err := ac.authService.Logout(cmd.Context(), token); err != nil {
+				return err
+			}
+
+			if err := ac.tokens.Clear(); err != nil {
+				return err
+			}
+
+			fmt.Println("Logged out")
+			return nil
+		},
+	}
+}
+
+func (ac *AuthCommands) timezoneCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "timezone [zone]",
+		Short:   "Show or set your timezone",
+		Long:    `Show or set the IANA timezone used to read and display due dates. "none" resets it to LOGGER_TIME_LOCATION`,
+		Example: `  taskmanager timezone taskmanager timezone Europe/Moscow taskmanager timezone none`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			principal, _ := auth.FromContext(cmd.Context())
+			if len(args) == 0 {
+				if principal.Timezone == "" {
+					fmt.Println("Timezone is not set, using the default")
+					return nil
+				}
+				fmt.Printf("Timezone: %s\n", principal.Timezone)
+				return nil
+			}
+
+			timezone := args[0]
+			if strings.EqualFold(timezone, "none") {
+				timezone = ""
+			}
+			if err := ac.authService.SetTimezone(cmd.Context(), timezone); err != nil {
+				return err
+			}
+
+			if timezone == "" {
+				fmt.Println("Timezone reset to the default")
+				return nil
+			}
+			fmt.Printf("Timezone set to %s\n", timezone)
+			return nil
+		},
+	}
+}
+
+func requiresAuth(cmd *cobra.Command) bool {
+	if hasAnnotation(cmd, skipAuthAnnotation) {
+		return false
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c.Name() {
+		case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return false
+		}
+	}
+	return cmd.Runnable() && cmd.HasParent()
+}
+
+func readPassword(prompt string) (string, error) {
+	fmt.Print(prompt)
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		password, err := term.ReadPassword(fd)
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		return string(password), nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"techno/internal/model"
+	"time"
+
+	"golang.org/x/term"
+)
+
+const (
+	dateTimeLayout = "2006-01-02 15:04"
+	// resolvedLayout echoes parsed --due values, so "next friday" can be
+	// checked at a glance.
+	resolvedLayout = "Mon 2006-01-02 15:04 MST"
+	colorRed       = "\033[31m"
+	colorReset     = "\033[0m"
+)
+
+func formatStatuses(statuses []model.TaskStatus) string {
+	if len(statuses) == 0 {
+		return "-"
+	}
+
+	names := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		names = append(names, status.StringStatus())
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatStatus shows unfinished tasks waiting for their dependencies as
+// blocked.
+func formatStatus(task *model.Task) string {
+	if task.IsWaiting() {
+		return fmt.Sprintf("blocked by %d", task.OpenDependencies)
+	}
+	return task.Status.StringStatus()
+}
+
+// printTaskRefs prints a labelled list of tasks, nothing when it is empty.
+func printTaskRefs(label string, tasks []*model.Task) {
+	if len(tasks) == 0 {
+		return
+	}
+
+	fmt.Println(label)
+	for _, task := range tasks {
+		fmt.Printf("  %s %s [%s]\n", task.DisplayID(), task.Title, formatStatus(task))
+	}
+}
+
+func formatDue(due *time.Time, loc *time.Location) string {
+	if due == nil {
+		return "-"
+	}
+	return due.In(loc).Format(dateTimeLayout)
+}
+
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	return strings.Join(tags, ", ")
+}
+
+func formatProject(key string) string {
+	if key == "" {
+		return "-"
+	}
+	return key
+}
+
+func truncate(s string, max int) string {
+	if len([]rune(s)) <= max {
+		return s
+	}
+	return string([]rune(s)[:max-3]) + "..."
+}
+
+// markOverdue prefixes a table line with a one column marker, "!" for
+// overdue tasks, and paints overdue lines red on color terminals.
+func markOverdue(line string, overdue bool) string {
+	if !overdue {
+		return " " + line
+	}
+	if !colorEnabled() {
+		return "!" + line
+	}
+	return colorRed + "!" + line + colorReset
+}
+
+func colorEnabled() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"techno/internal/model"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func (tc *TaskCommands) historyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history [id]",
+		Short: "Show the changes made to a task",
+		Long: "Print every change made to a task as a timeline: when, by whom and which fields changed. " +
+			"A plain id also works for tasks in the trash or the archive",
+		Example: `  taskmanager task history 7 taskmanager task history INFRA-12`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			events, err := tc.historyService.History(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			loc := location(cmd.Context(), tc.timezone)
+			for _, event := range events {
+				fmt.Printf("%s  %-12s %s\n", event.CreatedAt.In(loc).Format("2006-01-02 15:04:05"), event.Actor, eventTitle(event.Kind))
+				for _, change := range event.Changes {
+					old, value := formatField(change.Field, change.Old, loc), formatField(change.Field, change.New, loc)
+					if event.Kind == model.TaskCreated {
+						fmt.Printf("    %s: %s\n", change.Field, value)
+						continue
+					}
+					fmt.Printf("    %s: %s -> %s\n", change.Field, old, value)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func eventTitle(kind model.TaskEventKind) string {
+	switch kind {
+	case model.TaskCreated:
+		return "created"
+	case model.TaskUpdated:
+		return "updated"
+	case model.TaskDeleted:
+		return "moved to trash"
+	case model.TaskRestored:
+		return "restored"
+	case model.TaskArchived:
+		return "archived"
+	default:
+		return string(kind)
+	}
+}
+
+// formatField shows a field value of a task event, quoting the free text
+// fields and showing the due date in the user's timezone.
+func formatField(field, value string, loc *time.Location) string {
+	if value == "" {
+		return "-"
+	}
+	switch field {
+	case model.FieldTitle, model.FieldDescription:
+		return fmt.Sprintf("%q", value)
+	case model.FieldDue:
+		due, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return value
+		}
+		return formatDue(&due, loc)
+	case model.FieldTags:
+		return formatTags(strings.Split(value, ","))
+	default:
+		return value
+	}
+}
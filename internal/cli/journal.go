@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"techno/internal/model"
+	"techno/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+type JournalCommands struct {
+	journalService service.JournalService
+}
+
+func NewJournalCommands(journalService service.JournalService) *JournalCommands {
+	return &JournalCommands{
+		journalService: journalService,
+	}
+}
+
+func (jc *JournalCommands) RegisterCommands(rootCmd *cobra.Command) {
+	rootCmd.AddCommand(jc.undoCmd())
+	rootCmd.AddCommand(jc.redoCmd())
+}
+
+func (jc *JournalCommands) undoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "undo [n]",
+		Short: "Undo your last task changes",
+		Long: "Undo the last n task creates, updates and deletes you made, the last one by default. " +
+			"A change is not undone if the task was changed after it",
+		Example: `  taskmanager undo taskmanager undo 3`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n := 1
+			if len(args) == 1 {
+				var err error
+				if n, err = strconv.Atoi(args[0]); err != nil {
+					return fmt.Errorf("%w: invalid number of operations %q", model.ErrInvalidInput, args[0])
+				}
+			}
+
+			undone, err := jc.journalService.Undo(cmd.Context(), n)
+			for _, op := range undone {
+				fmt.Printf("Undone: %s\n", formatOperation(op))
+			}
+			return err
+		},
+	}
+}
+
+func (jc *JournalCommands) redoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "redo",
+		Short: "Redo the change you undid last",
+		Long:  "Redo the change you undid last. Undone changes cannot be redone once you make a new one",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			op, err := jc.journalService.Redo(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Redone: %s\n", formatOperation(op))
+			return nil
+		},
+	}
+}
+
+func formatOperation(op *model.Operation) string {
+	task := op.Task()
+	return fmt.Sprintf("%s of task %s %q", op.Kind, task.DisplayID(), task.Title)
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"techno/internal/migrator"
+
+	"github.com/pressly/goose/v3"
+	"github.com/spf13/cobra"
+)
+
+// skipSchemaCheckAnnotation marks commands that can run against a database
+// whose schema is older than the binary expects.
+const skipSchemaCheckAnnotation = "skip-schema-check"
+
+type MigrateCommands struct {
+	migrator *migrator.Migrator
+}
+
+// NewMigrateCommands accepts a nil migrator for storages without a schema.
+func NewMigrateCommands(m *migrator.Migrator) *MigrateCommands {
+	return &MigrateCommands{
+		migrator: m,
+	}
+}
+
+func (mc *MigrateCommands) RegisterCommands(rootCmd *cobra.Command) {
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage database schema",
+		Long:  "Apply, roll back and inspect the embedded database migrations",
+		Annotations: map[string]string{
+			skipAuthAnnotation:        "true",
+			skipSchemaCheckAnnotation: "true",
+		},
+	}
+
+	migrateCmd.AddCommand(mc.upCmd())
+	migrateCmd.AddCommand(mc.downCmd())
+	migrateCmd.AddCommand(mc.statusCmd())
+	migrateCmd.AddCommand(mc.redoCmd())
+
+	rootCmd.AddCommand(migrateCmd)
+}
+
+// CheckSchema is a pre-run hook that stops every command except migrate
+// when the database schema is behind the binary.
+func (mc *MigrateCommands) CheckSchema(cmd *cobra.Command, args []string) error {
+	if mc.migrator == nil || hasAnnotation(cmd, skipSchemaCheckAnnotation) || !requiresAuth(cmd) {
+		return nil
+	}
+	return mc.migrator.CheckVersion(cmd.Context())
+}
+
+func (mc *MigrateCommands) upCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "up",
+		Short: "Apply all pending migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := mc.requireMigrator(); err != nil {
+				return err
+			}
+
+			results, err := mc.migrator.Up(cmd.Context())
+			printResults(results)
+			if err != nil {
+				return fmt.Errorf("failed to apply migrations: %w", err)
+			}
+			if len(results) == 0 {
+				fmt.Println("No pending migrations")
+			}
+			return nil
+		},
+	}
+}
+
+func (mc *MigrateCommands) downCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "down",
+		Short: "Roll back the latest migration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := mc.requireMigrator(); err != nil {
+				return err
+			}
+
+			result, err := mc.migrator.Down(cmd.Context())
+			if err != nil {
+				if errors.Is(err, goose.ErrNoNextVersion) {
+					fmt.Println("No migrations to roll back")
+					return nil
+				}
+				return fmt.Errorf("failed to roll back migration: %w", err)
+			}
+			printResults([]*goose.MigrationResult{result})
+			return nil
+		},
+	}
+}
+
+func (mc *MigrateCommands) redoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "redo",
+		Short: "Roll back and re-apply the latest migration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := mc.requireMigrator(); err != nil {
+				return err
+			}
+
+			results, err := mc.migrator.Redo(cmd.Context())
+			printResults(results)
+			if err != nil {
+				return fmt.Errorf("failed to redo migration: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+func (mc *MigrateCommands) statusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show applied and pending migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := mc.requireMigrator(); err != nil {
+				return err
+			}
+
+			statuses, err := mc.migrator.Status(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to get migration status: %w", err)
+			}
+
+			fmt.Printf("\n%-22s %-10s %s\n", "Applied At", "State", "Migration")
+			for _, st := range statuses {
+				appliedAt := "-"
+				if st.State == goose.StateApplied {
+					appliedAt = st.AppliedAt.Format("2006-01-02 15:04:05")
+				}
+				fmt.Printf("%-22s %-10s %s\n", appliedAt, st.State, st.Source.Path)
+			}
+
+			current, latest, err := mc.migrator.Versions(cmd.Context())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nCurrent version: %d, latest: %d\n\n", current, latest)
+			return nil
+		},
+	}
+}
+
+func (mc *MigrateCommands) requireMigrator() error {
+	if mc.migrator == nil {
+		return errors.New("current storage driver has no database schema to migrate")
+	}
+	return nil
+}
+
+func printResults(results []*goose.MigrationResult) {
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		if r.Error != nil {
+			fmt.Printf("FAIL %-5s %s: %v\n", r.Direction, r.Source.Path, r.Error)
+			continue
+		}
+		fmt.Printf("OK   %-5s %s (%s)\n", r.Direction, r.Source.Path, r.Duration.Round(1e6))
+	}
+}
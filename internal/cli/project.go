@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"techno/internal/model"
+	"techno/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+type ProjectCommands struct {
+	projectService service.ProjectService
+}
+
+func NewProjectCommands(projectService service.ProjectService) *ProjectCommands {
+	return &ProjectCommands{
+		projectService: projectService,
+	}
+}
+
+func (pc *ProjectCommands) RegisterCommands(rootCmd *cobra.Command) {
+	projectCmd := &cobra.Command{
+		Use:   "project",
+		Short: "Manage projects",
+		Long:  "Create, list and archive projects. Tasks in a project get ids like INFRA-12",
+	}
+
+	projectCmd.AddCommand(pc.createCmd())
+	projectCmd.AddCommand(pc.listCmd())
+	projectCmd.AddCommand(pc.archiveCmd())
+
+	rootCmd.AddCommand(projectCmd)
+}
+
+func (pc *ProjectCommands) createCmd() *cobra.Command {
+	var key, name, description string
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Create a new project",
+		Example: `  taskmanager project create -k INFRA -n "Infrastructure" -d "Servers and CI"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project := &model.Project{
+				Key:         key,
+				Name:        name,
+				Description: description,
+			}
+			if err := pc.projectService.Create(cmd.Context(), project); err != nil {
+				return err
+			}
+
+			fmt.Printf("Project %s created\n", project.Key)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&key, "key", "k", "", "Project key, 2-10 latin letters and digits (required)")
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Project name (required)")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "Project description")
+	cmd.MarkFlagRequired("key")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func (pc *ProjectCommands) listCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List projects with the number of tasks in them",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projects, err := pc.projectService.List(cmd.Context(), all)
+			if err != nil {
+				return err
+			}
+			if len(projects) == 0 {
+				fmt.Println("No projects found")
+				return nil
+			}
+
+			fmt.Printf("\n%-10s %-30s %-6s %s\n", "Key", "Name", "Tasks", "State")
+			for _, project := range projects {
+				state := "active"
+				if project.IsArchived() {
+					state = "archived"
+				}
+				fmt.Printf("%-10s %-30s %-6d %s\n", project.Key, truncate(project.Name, 30), project.Tasks, state)
+			}
+			fmt.Printf("\nTotal: %d project(s)\n\n", len(projects))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Include archived projects")
+
+	return cmd
+}
+
+func (pc *ProjectCommands) archiveCmd() *cobra.Command {
+	var restore bool
+
+	cmd := &cobra.Command{
+		Use:     "archive [key]",
+		Short:   "Archive a project",
+		Long:    "Archive a project, its tasks are hidden from task list unless --project is given. --restore brings it back",
+		Example: `  taskmanager project archive INFRA taskmanager project archive INFRA --restore`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if restore {
+				if err := pc.projectService.Restore(cmd.Context(), args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Project %s restored\n", args[0])
+				return nil
+			}
+
+			if err := pc.projectService.Archive(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Project %s archived\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&restore, "restore", false, "Restore an archived project")
+
+	return cmd
+}
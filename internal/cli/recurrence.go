@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"techno/internal/duedate"
+	"techno/internal/model"
+	"techno/internal/service"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type RecurrenceCommands struct {
+	recurrenceService service.RecurrenceService
+	timezone          string
+}
+
+func NewRecurrenceCommands(recurrenceService service.RecurrenceService, timezone string) *RecurrenceCommands {
+	return &RecurrenceCommands{
+		recurrenceService: recurrenceService,
+		timezone:          timezone,
+	}
+}
+
+func (rc *RecurrenceCommands) RegisterCommands(rootCmd *cobra.Command) {
+	recurCmd := &cobra.Command{
+		Use:   "recur",
+		Short: "Manage recurring tasks",
+		Long: "Manage task templates with a cron-style schedule. The worker creates a task from a template " +
+			"when its next occurrence is scheduled or as soon as the previous one is finished",
+	}
+
+	recurCmd.AddCommand(rc.addCmd())
+	recurCmd.AddCommand(rc.listCmd())
+	recurCmd.AddCommand(rc.deleteCmd())
+
+	rootCmd.AddCommand(recurCmd)
+}
+
+func (rc *RecurrenceCommands) addCmd() *cobra.Command {
+	var title, description, rule, priorityStr, project, dueIn string
+	var tags []string
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a recurring task",
+		Long: `Add a recurring task. The rule has the cron fields "minute hour day-of-month month day-of-week" ` +
+			`and is read in your timezone, @hourly, @daily, @weekly and @monthly work too`,
+		Example: `  taskmanager recur add -t "Weekly report" --rule "0 9 * * MON" --due-in 8h taskmanager recur add -t "Pay rent" --rule "0 10 1 * *" --tag home`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loc := location(cmd.Context(), rc.timezone)
+			rec := &model.Recurrence{
+				Rule:        rule,
+				Timezone:    loc.String(),
+				Title:       title,
+				Description: description,
+				Tags:        tags,
+				ProjectKey:  project,
+			}
+
+			if priorityStr != "" {
+				priority, err := model.ParseTaskPriority(priorityStr)
+				if err != nil {
+					return err
+				}
+				rec.Priority = priority
+			}
+			if dueIn != "" {
+				d, err := duedate.ParseDuration(dueIn)
+				if err != nil {
+					return err
+				}
+				rec.DueIn = d
+			}
+
+			if err := rc.recurrenceService.Create(cmd.Context(), rec); err != nil {
+				return err
+			}
+
+			fmt.Printf("Recurring task %d created\n", rec.ID)
+			fmt.Printf("Rule: %s (%s)\n", rec.Rule, rec.Timezone)
+			fmt.Printf("Next: %s\n", rec.NextAt.In(loc).Format(resolvedLayout))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "", "Title of the created tasks (required)")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "Description of the created tasks")
+	cmd.Flags().StringVarP(&rule, "rule", "r", "", `Schedule, e.g. "0 9 * * MON", "30 8 * * 1-5", "@daily" (required)`)
+	cmd.Flags().StringVarP(&priorityStr, "priority", "p", "", "Task priority (low/medium/high/critical), medium by default")
+	cmd.Flags().StringArrayVar(&tags, "tag", nil, "Tag the created tasks, repeatable")
+	cmd.Flags().StringVar(&project, "project", "", "Project key of the created tasks")
+	cmd.Flags().StringVar(&dueIn, "due-in", "", "Make each task due this long after its scheduled time, e.g. 8h, 2d")
+	cmd.MarkFlagRequired("title")
+	cmd.MarkFlagRequired("rule")
+
+	return cmd
+}
+
+func (rc *RecurrenceCommands) listCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List recurring tasks",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loc := location(cmd.Context(), rc.timezone)
+			recurrences, err := rc.recurrenceService.List(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if len(recurrences) == 0 {
+				fmt.Println("No recurring tasks found")
+				return nil
+			}
+
+			fmt.Printf("\n%-5s %-30s %-18s %-18s %-10s %s\n", "ID", "Title", "Rule", "Next", "Project", "Due in")
+			for _, rec := range recurrences {
+				fmt.Printf("%-5d %-30s %-18s %-18s %-10s %s\n",
+					rec.ID,
+					truncate(rec.Title, 30),
+					truncate(rec.Rule, 18),
+					rec.NextAt.In(loc).Format(dateTimeLayout),
+					formatProject(rec.ProjectKey),
+					formatDueIn(rec.DueIn),
+				)
+			}
+			fmt.Printf("\nTotal: %d recurring task(s)\n\n", len(recurrences))
+			return nil
+		},
+	}
+}
+
+func (rc *RecurrenceCommands) deleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete [id]",
+		Short: "Stop a recurring task",
+		Long:  "Stop a recurring task. Tasks it already created are kept",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("%w: invalid recurrence id %q", model.ErrInvalidInput, args[0])
+			}
+			if err := rc.recurrenceService.Delete(cmd.Context(), id); err != nil {
+				return err
+			}
+
+			fmt.Printf("Recurring task %d deleted\n", id)
+			return nil
+		},
+	}
+}
+
+func formatDueIn(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.String()
+}
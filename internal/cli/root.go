@@ -4,11 +4,31 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func NewRootCommand() *cobra.Command {
+type PreRunHook func(cmd *cobra.Command, args []string) error
+
+func NewRootCommand(hooks ...PreRunHook) *cobra.Command {
 	return &cobra.Command{
-		Use:   "taskmanager",
-		Short: "task management cli",
-		Long: `A command line interface application for managing your tasks. You can create, list, update, and delete tasks`,
+		Use:     "taskmanager",
+		Short:   "task management cli",
+		Long:    `A command line interface application for managing your tasks. You can create, list, update, and delete tasks`,
 		Version: "1.0.0",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			for _, hook := range hooks {
+				if err := hook(cmd, args); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// hasAnnotation reports whether cmd or any of its parents sets key.
+func hasAnnotation(cmd *cobra.Command, key string) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[key] == "true" {
+			return true
+		}
 	}
+	return false
 }
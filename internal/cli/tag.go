@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"fmt"
+	"techno/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+type TagCommands struct {
+	tagService service.TagService
+}
+
+func NewTagCommands(tagService service.TagService) *TagCommands {
+	return &TagCommands{
+		tagService: tagService,
+	}
+}
+
+func (tc *TagCommands) RegisterCommands(rootCmd *cobra.Command) {
+	tagCmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Manage tags",
+		Long:  "List, rename, merge and delete the tags used on your tasks",
+	}
+
+	tagCmd.AddCommand(tc.listCmd())
+	tagCmd.AddCommand(tc.renameCmd())
+	tagCmd.AddCommand(tc.mergeCmd())
+	tagCmd.AddCommand(tc.deleteCmd())
+
+	rootCmd.AddCommand(tagCmd)
+}
+
+func (tc *TagCommands) listCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List tags with the number of tasks using them",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tags, err := tc.tagService.List(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if len(tags) == 0 {
+				fmt.Println("No tags found")
+				return nil
+			}
+
+			fmt.Printf("\n%-32s %s\n", "Tag", "Tasks")
+			for _, tag := range tags {
+				fmt.Printf("%-32s %d\n", tag.Name, tag.Tasks)
+			}
+			fmt.Printf("\nTotal: %d tag(s)\n\n", len(tags))
+			return nil
+		},
+	}
+}
+
+func (tc *TagCommands) renameCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "rename [old] [new]",
+		Short:   "Rename a tag on every task",
+		Example: `  taskmanager tag rename infra ops`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := tc.tagService.Rename(cmd.Context(), args[0], args[1]); err != nil {
+				return err
+			}
+
+			fmt.Printf("Tag %s renamed to %s\n", args[0], args[1])
+			return nil
+		},
+	}
+}
+
+func (tc *TagCommands) mergeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "merge [tag...] [into]",
+		Short:   "Merge tags into one",
+		Long:    "Replace every given tag with the last one on all tasks and delete the merged tags",
+		Example: `  taskmanager tag merge docs documentation doc`,
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sources, target := args[:len(args)-1], args[len(args)-1]
+			if err := tc.tagService.Merge(cmd.Context(), sources, target); err != nil {
+				return err
+			}
+
+			fmt.Printf("Merged %d tag(s) into %s\n", len(sources), target)
+			return nil
+		},
+	}
+}
+
+func (tc *TagCommands) deleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete [tag]",
+		Short:   "Delete a tag",
+		Long:    "Remove a tag from every task and delete it, the tasks are kept",
+		Example: `  taskmanager tag delete obsolete`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := tc.tagService.Delete(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+
+			fmt.Printf("Tag %s deleted\n", args[0])
+			return nil
+		},
+	}
+}
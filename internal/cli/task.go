@@ -1,22 +1,30 @@
 package cli
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"strings"
 	"techno/internal/model"
 	"techno/internal/service"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 type TaskCommands struct {
-	taskService service.TaskService
+	taskService       service.TaskService
+	dependencyService service.DependencyService
+	historyService    service.HistoryService
+	// timezone is used for users without their own timezone setting.
+	timezone string
 }
 
-func NewTaskCommands(taskService service.TaskService) *TaskCommands {
+func NewTaskCommands(taskService service.TaskService, dependencyService service.DependencyService, historyService service.HistoryService, timezone string) *TaskCommands {
 	return &TaskCommands{
-		taskService: taskService,
+		taskService:       taskService,
+		dependencyService: dependencyService,
+		historyService:    historyService,
+		timezone:          timezone,
 	}
 }
 
@@ -29,89 +37,224 @@ func (tc *TaskCommands) RegisterCommands(rootCmd *cobra.Command) {
 
 	taskCmd.AddCommand(tc.createCmd())
 	taskCmd.AddCommand(tc.listCmd())
+	taskCmd.AddCommand(tc.nextCmd())
 	taskCmd.AddCommand(tc.getCmd())
 	taskCmd.AddCommand(tc.updateCmd())
 	taskCmd.AddCommand(tc.deleteCmd())
+	taskCmd.AddCommand(tc.dependCmd())
+	taskCmd.AddCommand(tc.historyCmd())
 
 	rootCmd.AddCommand(taskCmd)
 }
 
 func (tc *TaskCommands) createCmd() *cobra.Command {
-	var title, description string
+	var title, description, dueStr, priorityStr, project, parent string
+	var tags []string
 
 	cmd := &cobra.Command{
 		Use:     "create",
 		Short:   "Create a new task",
 		Long:    "Create a new task with title and optional description",
-		Example: `  taskmanager task create -t "Buy groceries" -d "Milk, bread, eggs" taskmanager task create --title "Meeting" --description "Team sync at 3pm"`,
+		Example: `  taskmanager task create -t "Buy groceries" -d "Milk, bread, eggs" taskmanager task create --title "Meeting" --description "Team sync at 3pm" --due "tomorrow 15:00"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			loc := location(cmd.Context(), tc.timezone)
 			task := &model.Task{
 				Title:       title,
 				Description: description,
+				Tags:        tags,
+				ProjectKey:  project,
 			}
 
-			if err := tc.taskService.CreateTask(context.Background(), task); err != nil {
+			if parent != "" {
+				parentTask, err := tc.taskService.GetByRef(cmd.Context(), parent)
+				if err != nil {
+					return fmt.Errorf("failed to get parent task: %w", err)
+				}
+				task.ParentID = &parentTask.ID
+			}
+
+			if dueStr != "" {
+				due, err := parseDue(dueStr, loc)
+				if err != nil {
+					return err
+				}
+				task.DueAt = due
+			}
+			if priorityStr != "" {
+				priority, err := model.ParseTaskPriority(priorityStr)
+				if err != nil {
+					return err
+				}
+				task.Priority = priority
+			}
+
+			if err := tc.taskService.CreateTask(cmd.Context(), task); err != nil {
 				return fmt.Errorf("failed to create task: %w", err)
 			}
 
 			fmt.Printf("Task created successfull\n")
-			fmt.Printf("ID: %d\n", task.ID)
+			fmt.Printf("ID: %s\n", task.DisplayID())
 			fmt.Printf("Title: %s\n", task.Title)
 			fmt.Printf("Status: %s\n", task.Status.StringStatus())
+			fmt.Printf("Priority: %s\n", task.Priority.StringPriority())
+			if len(task.Tags) > 0 {
+				fmt.Printf("Tags: %s\n", formatTags(task.Tags))
+			}
+			if task.DueAt != nil {
+				fmt.Printf("Due: %s\n", task.DueAt.In(loc).Format(resolvedLayout))
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&title, "title", "t", "", "Task title (required)")
 	cmd.Flags().StringVarP(&description, "description", "d", "", "Task description")
+	cmd.Flags().StringVar(&dueStr, "due", "", `Due date: YYYY-MM-DD [HH:MM] or "tomorrow 17:00", "next friday", "in 3 days", "eow", "завтра", "через 2 дня"`)
+	cmd.Flags().StringVarP(&priorityStr, "priority", "p", "", "Task priority (low/medium/high/critical), medium by default")
+	cmd.Flags().StringArrayVar(&tags, "tag", nil, "Tag the task, repeatable")
+	cmd.Flags().StringVar(&project, "project", "", "Project key, the task gets an id like INFRA-12")
+	cmd.Flags().StringVar(&parent, "parent", "", "Make the task a subtask of this task")
 	cmd.MarkFlagRequired("title")
 
 	return cmd
 }
 
 func (tc *TaskCommands) listCmd() *cobra.Command {
-	var statusStr string
+	var statusStr, dueBeforeStr, dueAfterStr, sortStr, project, asOfStr string
+	var overdue, allTags, tree bool
+	var tags []string
 
 	cmd := &cobra.Command{
 		Use:     "list",
 		Short:   "List all tasks",
-		Long:    "List all tasks or filter by status",
-		Example: `  taskmanager task list taskmanager task list -s pending taskmanager task list --status completed`,
+		Long:    "List all tasks or filter by status and due date. Overdue tasks are highlighted. With --as-of the list is rebuilt as it was at that time from the task history, without the blocked by dependencies marker since dependencies are not in the history. Archived projects are judged by their current state: a project restored since then shows its tasks, one archived again hides them from its last archiving on",
+		Example: `  taskmanager task list taskmanager task list -s pending taskmanager task list --overdue taskmanager task list --due-before 2026-11-01 taskmanager task list --sort priority,due,created taskmanager task list --tag backend --tag infra --all-tags taskmanager task list --project INFRA taskmanager task list --tree taskmanager task list --as-of "2026-10-01 09:00"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var tasks []*model.Task
-			var err error
+			loc := location(cmd.Context(), tc.timezone)
+			var filter model.TaskFilter
 
 			if statusStr != "" {
-				taskStatus := model.ParseTaskStatus(statusStr)
-				tasks, err = tc.taskService.GetByStatus(context.Background(), taskStatus)
+				taskStatus, err := model.ParseTaskStatus(statusStr)
 				if err != nil {
-					return fmt.Errorf("failed to get tasks by status: %w", err)
+					return err
 				}
-			} else {
-				tasks, err = tc.taskService.GetAll(context.Background())
+				filter.Status = &taskStatus
+			}
+			if dueBeforeStr != "" {
+				dueBefore, err := parseTime(dueBeforeStr, loc)
+				if err != nil {
+					return err
+				}
+				filter.DueBefore = &dueBefore
+			}
+			if dueAfterStr != "" {
+				dueAfter, err := parseTime(dueAfterStr, loc)
 				if err != nil {
-					return fmt.Errorf("failed to get all tasks: %w", err)
+					return err
 				}
+				filter.DueAfter = &dueAfter
+			}
+			filter.Overdue = overdue
+			filter.Tags = tags
+			filter.AllTags = allTags
+			filter.Project = project
+			if sortStr != "" {
+				keys, err := model.ParseSort(sortStr)
+				if err != nil {
+					return err
+				}
+				filter.Sort = keys
+			}
+
+			// Overdue marks are relative to the time the list shows.
+			now := time.Now()
+			var tasks []*model.Task
+			var err error
+			if asOfStr != "" {
+				if now, err = parseTime(asOfStr, loc); err != nil {
+					return err
+				}
+				tasks, err = tc.historyService.AsOf(cmd.Context(), now, filter)
+			} else {
+				tasks, err = tc.taskService.List(cmd.Context(), filter)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get tasks: %w", err)
 			}
 			if len(tasks) == 0 {
 				fmt.Println("No tasks found")
 				return nil
 			}
 
-			fmt.Printf("\n%-5s %-40s %-30s %-15s %-20s\n", "ID", "Title", "Description", "Status", "Created At")
-			for _, task := range tasks {
-				title := task.Title
-				if len(title) > 40 {
-					title = title[:37] + "..."
+			fmt.Printf("\n %-10s %-40s %-15s %-9s %-17s %-17s %s\n", "ID", "Title", "Status", "Priority", "Due", "Created At", "Tags")
+			rows := make([]treeRow, 0, len(tasks))
+			if tree {
+				rows = treeOrder(tasks)
+			} else {
+				for _, task := range tasks {
+					rows = append(rows, treeRow{task: task})
 				}
-				fmt.Printf("%-5d %-40s %-15s %-20s\n", task.ID, title, task.Status.StringStatus(), task.CreatedAt.Format("2006-01-02 15:04"))
+			}
+			for _, row := range rows {
+				task := row.task
+				line := fmt.Sprintf("%-10s %-40s %-15s %-9s %-17s %-17s %s", task.DisplayID(), truncate(treePrefix(row.depth)+task.Title, 40), formatStatus(task), task.Priority.StringPriority(), formatDue(task.DueAt, loc), task.CreatedAt.In(loc).Format(dateTimeLayout), formatTags(task.Tags))
+				fmt.Println(markOverdue(line, task.IsOverdue(now)))
 			}
 			fmt.Printf("\nTotal: %d task(s)\n\n", len(tasks))
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&statusStr, "status", "s", "", "Filter by status (done/not_done)")
+	cmd.Flags().StringVarP(&statusStr, "status", "s", "", "Filter by status (not_done/in_progress/blocked/done/cancelled)")
+	cmd.Flags().BoolVar(&overdue, "overdue", false, "Only unfinished tasks past their due date")
+	cmd.Flags().StringVar(&dueBeforeStr, "due-before", "", `Only tasks due before this time (absolute or natural, e.g. "eow")`)
+	cmd.Flags().StringVar(&dueAfterStr, "due-after", "", "Only tasks due after this time")
+	cmd.Flags().StringArrayVar(&tags, "tag", nil, "Only tasks with this tag, repeatable")
+	cmd.Flags().BoolVar(&allTags, "all-tags", false, "Require every --tag instead of any of them")
+	cmd.Flags().StringVar(&project, "project", "", "Only tasks of this project, also lists archived projects")
+	cmd.Flags().BoolVar(&tree, "tree", false, "Show subtasks under their parents")
+	cmd.Flags().StringVar(&sortStr, "sort", "", `Sort keys, comma separated: priority, due, created; "-" reverses a key (default: newest first)`)
+	cmd.Flags().StringVar(&asOfStr, "as-of", "", `Show the tasks as they were at this time, e.g. "2026-10-01 09:00"`)
+	return cmd
+}
+
+func (tc *TaskCommands) nextCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:     "next",
+		Short:   "Show the most urgent tasks",
+		Long:    "Rank unfinished tasks by urgency from priority, due date, age, tags and blockers, and explain each score. The coefficients are set with the URGENCY_* variables",
+		Example: `  taskmanager task next taskmanager task next -n 10`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ranked, err := tc.taskService.Next(cmd.Context(), limit)
+			if err != nil {
+				return fmt.Errorf("failed to rank tasks: %w", err)
+			}
+			if len(ranked) == 0 {
+				fmt.Println("Nothing to do")
+				return nil
+			}
+
+			fmt.Printf("\n %-3s %-10s %-40s %-15s %s\n", "#", "ID", "Title", "Status", "Urgency")
+			for i, item := range ranked {
+				task := item.Task
+				fmt.Printf(" %-3d %-10s %-40s %-15s %7.2f\n", i+1, task.DisplayID(), truncate(task.Title, 40), formatStatus(task), item.Urgency.Score)
+				for _, part := range item.Urgency.Parts {
+					fmt.Printf("     %-16s %+7.2f = %5.2f x %.2f", part.Name, part.Value(), part.Coefficient, part.Factor)
+					if part.Reason != "" {
+						fmt.Printf("  (%s)", part.Reason)
+					}
+					fmt.Println()
+				}
+			}
+			fmt.Println()
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 5, "Number of tasks to show")
+
 	return cmd
 }
 
@@ -119,48 +262,87 @@ func (tc *TaskCommands) getCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:     "get [id]",
 		Short:   "Get task by ID",
-		Long:    "Display detailed information about a specific task",
-		Example: `  taskmanager task get 1 taskmanager task get 42`,
+		Long:    "Display detailed information about a specific task, the id can be project scoped (INFRA-12)",
+		Example: `  taskmanager task get 1 taskmanager task get 42 taskmanager task get INFRA-12`,
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(args[0])
-			if err != nil {
-				return fmt.Errorf("invalid task ID: %w", err)
-			}
-
-			task, err := tc.taskService.GetByID(context.Background(), id)
+			task, err := tc.taskService.GetByRef(cmd.Context(), args[0])
 			if err != nil {
 				return fmt.Errorf("failed to get task: %w", err)
 			}
-			fmt.Printf("ID:          %d\n", task.ID)
+			fmt.Printf("ID:          %s\n", task.DisplayID())
 			fmt.Printf("Title:       %s\n", task.Title)
 			fmt.Printf("Description: %s\n", task.Description)
-			fmt.Printf("Status:      %s\n", task.Status.StringStatus())
-			fmt.Printf("Created At:  %s\n", task.CreatedAt.Format("2006-01-02 15:04:05"))
+			fmt.Printf("Status:      %s\n", formatStatus(task))
+			fmt.Printf("Priority:    %s\n", task.Priority.StringPriority())
+			fmt.Printf("Project:     %s\n", formatProject(task.ProjectKey))
+			fmt.Printf("Tags:        %s\n", formatTags(task.Tags))
+			fmt.Printf("Next status: %s\n", formatStatuses(tc.taskService.AllowedTransitions(task.Status)))
+			loc := location(cmd.Context(), tc.timezone)
+			due := formatDue(task.DueAt, loc)
+			if task.IsOverdue(time.Now()) {
+				due += " (overdue)"
+			}
+			fmt.Printf("Due:         %s\n", due)
+			fmt.Printf("Created At:  %s\n", task.CreatedAt.In(loc).Format("2006-01-02 15:04:05"))
+			fmt.Printf("Updated At:  %s\n", task.UpdatedAt.In(loc).Format("2006-01-02 15:04:05"))
+			if task.CompletedAt != nil {
+				fmt.Printf("Completed:   %s\n", task.CompletedAt.In(loc).Format("2006-01-02 15:04:05"))
+			}
+			if task.RecurrenceID != nil {
+				fmt.Printf("Recurring:   %d, occurrence of %s\n", *task.RecurrenceID, task.OccurrenceAt.In(loc).Format(dateTimeLayout))
+			}
+
+			if task.ParentID != nil {
+				parent, err := tc.taskService.GetByID(cmd.Context(), *task.ParentID)
+				if err != nil {
+					return fmt.Errorf("failed to get parent task: %w", err)
+				}
+				fmt.Printf("Parent:      %s %s\n", parent.DisplayID(), parent.Title)
+			}
+			subtasks, err := tc.taskService.GetSubtasks(cmd.Context(), task.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get subtasks: %w", err)
+			}
+			if len(subtasks) > 0 {
+				fmt.Println("Subtasks:")
+				for _, row := range treeOrder(subtasks) {
+					fmt.Printf("  %s%s %s [%s]\n", treePrefix(row.depth), row.task.DisplayID(), row.task.Title, row.task.Status.StringStatus())
+				}
+			}
+
+			dependencies, err := tc.dependencyService.Dependencies(cmd.Context(), task.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get dependencies: %w", err)
+			}
+			printTaskRefs("Depends on:", dependencies)
+			dependents, err := tc.dependencyService.Dependents(cmd.Context(), task.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get dependents: %w", err)
+			}
+			printTaskRefs("Blocks:", dependents)
 			return nil
 		},
 	}
 }
 
 func (tc *TaskCommands) updateCmd() *cobra.Command {
-	var title, description, statusStr string
+	var title, description, statusStr, dueStr, priorityStr, project, parent string
+	var tags []string
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:     "update [id]",
 		Short:   "Update a task",
-		Long:    "Update task title, description, or status",
-		Example: `  taskmanager task update 1 -t "New title" taskmanager task update 1 -s completed taskmanager task update 1 -t "New title" -d "New description" -s in_progress`,
+		Long:    "Update task title, description, status, priority, due date, tags, project or parent. A task with open subtasks can only be finished with --force",
+		Example: `  taskmanager task update 1 -t "New title" taskmanager task update 1 -s completed taskmanager task update 1 -t "New title" -d "New description" -s in_progress taskmanager task update INFRA-12 --project none`,
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(args[0])
-			if err != nil {
-				return fmt.Errorf("invalid task ID: %w", err)
-			}
-
-			existingTask, err := tc.taskService.GetByID(context.Background(), id)
+			existingTask, err := tc.taskService.GetByRef(cmd.Context(), args[0])
 			if err != nil {
 				return fmt.Errorf("failed to get task: %w", err)
 			}
+			wasFinished := existingTask.Status.IsFinished()
 
 			if title != "" {
 				existingTask.Title = title
@@ -169,42 +351,109 @@ func (tc *TaskCommands) updateCmd() *cobra.Command {
 				existingTask.Description = description
 			}
 			if statusStr != "" {
-				existingTask.Status = model.ParseTaskStatus(statusStr)
+				status, err := model.ParseTaskStatus(statusStr)
+				if err != nil {
+					return err
+				}
+				existingTask.Status = status
+			}
+			if priorityStr != "" {
+				priority, err := model.ParseTaskPriority(priorityStr)
+				if err != nil {
+					return err
+				}
+				existingTask.Priority = priority
+			}
+			if cmd.Flags().Changed("tag") {
+				existingTask.Tags = nil
+				if len(tags) != 1 || !strings.EqualFold(tags[0], "none") {
+					existingTask.Tags = tags
+				}
+			}
+			if cmd.Flags().Changed("project") {
+				existingTask.ProjectKey = ""
+				if !strings.EqualFold(project, "none") {
+					existingTask.ProjectKey = project
+				}
+			}
+			if cmd.Flags().Changed("parent") {
+				existingTask.ParentID = nil
+				if !strings.EqualFold(parent, "none") {
+					parentTask, err := tc.taskService.GetByRef(cmd.Context(), parent)
+					if err != nil {
+						return fmt.Errorf("failed to get parent task: %w", err)
+					}
+					existingTask.ParentID = &parentTask.ID
+				}
+			}
+			loc := location(cmd.Context(), tc.timezone)
+			if cmd.Flags().Changed("due") {
+				due, err := parseDue(dueStr, loc)
+				if err != nil {
+					return err
+				}
+				existingTask.DueAt = due
 			}
 
-			if err := tc.taskService.UpdateTask(context.Background(), existingTask); err != nil {
+			if err := tc.taskService.UpdateTask(cmd.Context(), existingTask, model.UpdateOptions{Force: force}); err != nil {
 				return fmt.Errorf("failed to update task: %w", err)
 			}
 
-			fmt.Printf("Task %d updated successfull\n", id)
+			fmt.Printf("Task %s updated successfull\n", existingTask.DisplayID())
+			if statusStr != "" {
+				fmt.Printf("Status: %s (next: %s)\n", existingTask.Status.StringStatus(), formatStatuses(tc.taskService.AllowedTransitions(existingTask.Status)))
+			}
+			if cmd.Flags().Changed("due") && existingTask.DueAt != nil {
+				fmt.Printf("Due: %s\n", existingTask.DueAt.In(loc).Format(resolvedLayout))
+			}
+			if existingTask.Status.IsFinished() && !wasFinished {
+				unblocked, err := tc.dependencyService.Unblocked(cmd.Context(), existingTask.ID)
+				if err != nil {
+					return fmt.Errorf("failed to get unblocked tasks: %w", err)
+				}
+				printTaskRefs("Unblocked:", unblocked)
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&title, "title", "t", "", "New task title")
 	cmd.Flags().StringVarP(&description, "description", "d", "", "New task description")
-	cmd.Flags().StringVarP(&statusStr, "status", "s", "", "New task status (done/not_done)")
+	cmd.Flags().StringVarP(&statusStr, "status", "s", "", "New task status (not_done/in_progress/blocked/done/cancelled)")
+	cmd.Flags().StringVarP(&priorityStr, "priority", "p", "", "New task priority (low/medium/high/critical)")
+	cmd.Flags().StringArrayVar(&tags, "tag", nil, `Replace the task tags, repeatable; "none" removes all tags`)
+	cmd.Flags().StringVar(&dueStr, "due", "", `New due date, absolute or natural ("tomorrow 17:00", "in 3 days"), "none" removes it`)
+	cmd.Flags().StringVar(&project, "project", "", `Move the task to a project, it gets the next id there; "none" takes it out`)
+	cmd.Flags().StringVar(&parent, "parent", "", `Move the task under another task; "none" makes it top level`)
+	cmd.Flags().BoolVar(&force, "force", false, "Finish the task even if some of its subtasks are open")
 
 	return cmd
 }
 
 func (tc *TaskCommands) deleteCmd() *cobra.Command {
 	var confirm bool
+	var childrenStr string
 
 	cmd := &cobra.Command{
-		Use:     "delete [id]",
-		Short:   "Delete a task",
-		Long:    "Delete a task by its ID",
-		Example: `  taskmanager task delete 1 taskmanager task delete 1 -y`,
+		Use:   "delete [id]",
+		Short: "Delete a task",
+		Long: "Move a task to the trash by its ID or project scoped ID, trash restore brings it back. " +
+			"A task with subtasks is kept unless --children says what to do with them",
+		Example: `  taskmanager task delete 1 taskmanager task delete 1 -y taskmanager task delete INFRA-12 taskmanager task delete 3 --children reparent`,
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(args[0])
+			policy, err := model.ParseChildPolicy(childrenStr)
 			if err != nil {
-				return fmt.Errorf("invalid task ID: %w", err)
+				return err
+			}
+
+			task, err := tc.taskService.GetByRef(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get task: %w", err)
 			}
 
 			if !confirm {
-				fmt.Printf("Are you sure you want to delete task %d? [y/N]: ", id)
+				fmt.Printf("Are you sure you want to delete task %s? [y/N]: ", task.DisplayID())
 				var response string
 				fmt.Scanln(&response)
 				if response != "y" && response != "Y" {
@@ -213,16 +462,83 @@ func (tc *TaskCommands) deleteCmd() *cobra.Command {
 				}
 			}
 
-			if err := tc.taskService.DeleteTask(context.Background(), id); err != nil {
+			if err := tc.taskService.DeleteTask(cmd.Context(), task.ID, policy); err != nil {
+				if errors.Is(err, model.ErrHasSubtasks) {
+					return fmt.Errorf("failed to delete task: %w, use --children cascade or --children reparent", err)
+				}
 				return fmt.Errorf("failed to delete task: %w", err)
 			}
 
-			fmt.Printf("Task %d deleted successfull\n", id)
+			fmt.Printf("Task %s moved to trash, restore it with `taskmanager trash restore %d`\n", task.DisplayID(), task.ID)
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVarP(&confirm, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().StringVar(&childrenStr, "children", "refuse", "What to do with subtasks: refuse, cascade (delete them) or reparent (move them up)")
 
 	return cmd
 }
+
+func (tc *TaskCommands) dependCmd() *cobra.Command {
+	dependCmd := &cobra.Command{
+		Use:   "depend",
+		Short: "Manage task dependencies",
+		Long:  "A task that depends on unfinished tasks is shown as blocked until they are done",
+	}
+
+	dependCmd.AddCommand(&cobra.Command{
+		Use:     "add [id] [on-id]",
+		Short:   "Make a task wait for another task",
+		Example: `  taskmanager task depend add 5 3 taskmanager task depend add INFRA-4 INFRA-2`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			task, on, err := tc.getPair(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			if err := tc.dependencyService.Add(cmd.Context(), task.ID, on.ID); err != nil {
+				return err
+			}
+
+			fmt.Printf("Task %s now depends on %s\n", task.DisplayID(), on.DisplayID())
+			return nil
+		},
+	})
+
+	dependCmd.AddCommand(&cobra.Command{
+		Use:     "remove [id] [on-id]",
+		Short:   "Remove a dependency",
+		Example: `  taskmanager task depend remove 5 3`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			task, on, err := tc.getPair(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			if err := tc.dependencyService.Remove(cmd.Context(), task.ID, on.ID); err != nil {
+				return err
+			}
+
+			fmt.Printf("Task %s no longer depends on %s\n", task.DisplayID(), on.DisplayID())
+			return nil
+		},
+	})
+
+	return dependCmd
+}
+
+// getPair resolves the two task references of a depend command.
+func (tc *TaskCommands) getPair(cmd *cobra.Command, args []string) (*model.Task, *model.Task, error) {
+	task, err := tc.taskService.GetByRef(cmd.Context(), args[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	on, err := tc.taskService.GetByRef(cmd.Context(), args[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	return task, on, nil
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"techno/internal/auth"
+	"techno/internal/duedate"
+	"time"
+)
+
+// location returns the zone times are read and shown in: the user's own
+// setting first, then the configured default, then the system zone.
+func location(ctx context.Context, fallback string) *time.Location {
+	if principal, ok := auth.FromContext(ctx); ok && principal.Timezone != "" {
+		if loc, err := time.LoadLocation(principal.Timezone); err == nil {
+			return loc
+		}
+	}
+	if loc, err := time.LoadLocation(fallback); err == nil {
+		return loc
+	}
+	return time.Local
+}
+
+// parseTime reads an absolute timestamp or a natural expression such as
+// "tomorrow 17:00" in loc. A bare date means the start of that day.
+func parseTime(input string, loc *time.Location) (time.Time, error) {
+	return duedate.Parse(input, time.Now().In(loc))
+}
+
+// parseDue is parseTime for --due flags, where "none" removes the due date.
+func parseDue(input string, loc *time.Location) (*time.Time, error) {
+	if strings.EqualFold(strings.TrimSpace(input), "none") {
+		return nil, nil
+	}
+
+	t, err := parseTime(input, loc)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"techno/internal/duedate"
+	"techno/internal/model"
+	"techno/internal/service"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type TrashCommands struct {
+	trashService service.TrashService
+	timezone     string
+}
+
+func NewTrashCommands(trashService service.TrashService, timezone string) *TrashCommands {
+	return &TrashCommands{
+		trashService: trashService,
+		timezone:     timezone,
+	}
+}
+
+func (tc *TrashCommands) RegisterCommands(rootCmd *cobra.Command) {
+	trashCmd := &cobra.Command{
+		Use:   "trash",
+		Short: "Manage deleted tasks",
+		Long: "Deleted tasks stay in the trash until it is emptied by hand or by the worker " +
+			"once they are older than CLEANER_TRASH_RETENTION",
+	}
+
+	trashCmd.AddCommand(tc.listCmd())
+	trashCmd.AddCommand(tc.restoreCmd())
+	trashCmd.AddCommand(tc.emptyCmd())
+
+	rootCmd.AddCommand(trashCmd)
+}
+
+func (tc *TrashCommands) listCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List deleted tasks, recently deleted first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loc := location(cmd.Context(), tc.timezone)
+			tasks, err := tc.trashService.List(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if len(tasks) == 0 {
+				fmt.Println("Trash is empty")
+				return nil
+			}
+
+			fmt.Printf("\n%-5s %-10s %-40s %-12s %-16s\n", "ID", "Task", "Title", "Status", "Deleted")
+			for _, task := range tasks {
+				fmt.Printf("%-5d %-10s %-40s %-12s %-16s\n",
+					task.ID,
+					task.DisplayID(),
+					truncate(task.Title, 40),
+					task.Status.StringStatus(),
+					formatDue(task.DeletedAt, loc),
+				)
+			}
+			fmt.Printf("\nTotal: %d deleted task(s)\n\n", len(tasks))
+			return nil
+		},
+	}
+}
+
+func (tc *TrashCommands) restoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore [id]",
+		Short: "Take a task out of the trash",
+		Long: "Take a task out of the trash together with the subtasks deleted along with it. " +
+			"If its parent is still in the trash the task becomes top level",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("%w: invalid task id %q", model.ErrInvalidInput, args[0])
+			}
+
+			tasks, err := tc.trashService.Restore(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+
+			task := tasks[0]
+			fmt.Printf("Task %s restored: %s [%s]\n", task.DisplayID(), task.Title, task.Status.StringStatus())
+			printTaskRefs("Restored subtasks:", tasks[1:])
+			return nil
+		},
+	}
+}
+
+func (tc *TrashCommands) emptyCmd() *cobra.Command {
+	var olderThan string
+	var confirm bool
+
+	cmd := &cobra.Command{
+		Use:     "empty",
+		Short:   "Delete tasks in the trash for good",
+		Example: `  taskmanager trash empty taskmanager trash empty -y taskmanager trash empty --older-than 30d`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var age time.Duration
+			if olderThan != "" {
+				d, err := duedate.ParseDuration(olderThan)
+				if err != nil {
+					return err
+				}
+				age = d
+			}
+
+			if !confirm {
+				if age > 0 {
+					fmt.Printf("Are you sure you want to delete for good the tasks deleted more than %s ago? [y/N]: ", olderThan)
+				} else {
+					fmt.Print("Are you sure you want to delete all tasks in the trash for good? [y/N]: ")
+				}
+				var response string
+				fmt.Scanln(&response)
+				if response != "y" && response != "Y" {
+					fmt.Println("Emptying cancelled")
+					return nil
+				}
+			}
+
+			purged, err := tc.trashService.Empty(cmd.Context(), age)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Deleted %d task(s) for good\n", purged)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&confirm, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Only delete tasks deleted this long ago, e.g. 30d, 2w; all of them by default")
+
+	return cmd
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"strings"
+	"techno/internal/model"
+)
+
+// treeRow is a task with the depth it is printed at in a tree view.
+type treeRow struct {
+	task  *model.Task
+	depth int
+}
+
+// treeOrder puts every subtask right below its parent and otherwise keeps
+// the order of tasks. Tasks whose parent is not in the list are roots.
+func treeOrder(tasks []*model.Task) []treeRow {
+	present := make(map[int]bool, len(tasks))
+	for _, task := range tasks {
+		present[task.ID] = true
+	}
+
+	children := make(map[int][]*model.Task)
+	var roots []*model.Task
+	for _, task := range tasks {
+		if task.ParentID != nil && present[*task.ParentID] {
+			children[*task.ParentID] = append(children[*task.ParentID], task)
+			continue
+		}
+		roots = append(roots, task)
+	}
+
+	rows := make([]treeRow, 0, len(tasks))
+	var walk func(task *model.Task, depth int)
+	walk = func(task *model.Task, depth int) {
+		rows = append(rows, treeRow{task: task, depth: depth})
+		for _, child := range children[task.ID] {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		walk(root, 0)
+	}
+	return rows
+}
+
+// treePrefix indents a title by its depth in the tree.
+func treePrefix(depth int) string {
+	if depth == 0 {
+		return ""
+	}
+	return strings.Repeat("  ", depth-1) + "└ "
+}
@@ -0,0 +1,158 @@
+package cleaner
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"techno/internal/duedate"
+	"techno/internal/model"
+	"time"
+)
+
+const (
+	cleanerIntervalEnvName         = "CLEANER_INTERVAL"
+	cleanerModeEnvName             = "CLEANER_MODE"
+	cleanerRetentionEnvName        = "CLEANER_RETENTION"
+	cleanerRetentionProjectEnvName = "CLEANER_RETENTION_PROJECT"
+	cleanerRetentionTagEnvName     = "CLEANER_RETENTION_TAG"
+	cleanerTrashRetentionEnvName   = "CLEANER_TRASH_RETENTION"
+
+	// keepForever is how the variables spell model.KeepForever.
+	keepForever = "never"
+)
+
+// Mode is what the cleaner does with an expired task.
+type Mode string
+
+const (
+	// ModeArchive moves expired tasks to the archive.
+	ModeArchive Mode = "archive"
+	// ModeDelete moves expired tasks to the trash, which the worker empties
+	// after TrashRetention.
+	ModeDelete Mode = "delete"
+)
+
+type CleanerConfig interface {
+	// Interval is how often the worker looks for done tasks to delete.
+	Interval() time.Duration
+	Mode() Mode
+	Policy() model.RetentionPolicy
+	// TrashRetention is how long deleted tasks stay in the trash,
+	// model.KeepForever keeps them until the trash is emptied by hand.
+	TrashRetention() time.Duration
+}
+
+type cleanerConfig struct {
+	interval time.Duration
+	mode     Mode
+	policy   model.RetentionPolicy
+	trash    time.Duration
+}
+
+// NewCleanerConfig reads the schedule and retention policy of the task
+// cleaner. Durations take the units of time.ParseDuration plus d and w,
+// retentions also take "never". The override variables take "key=retention"
+// pairs separated by commas.
+func NewCleanerConfig() (CleanerConfig, error) {
+	// The defaults keep the old behaviour: done tasks are deleted on the
+	// next run, every 30 seconds.
+	cfg := &cleanerConfig{
+		interval: 30 * time.Second,
+		mode:     ModeDelete,
+		trash:    30 * 24 * time.Hour,
+	}
+
+	if raw := os.Getenv(cleanerIntervalEnvName); raw != "" {
+		interval, err := duedate.ParseDuration(raw)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("%s must be a positive duration, got %q", cleanerIntervalEnvName, raw)
+		}
+		cfg.interval = interval
+	}
+
+	if raw := os.Getenv(cleanerModeEnvName); raw != "" {
+		switch mode := Mode(strings.ToLower(strings.TrimSpace(raw))); mode {
+		case ModeArchive, ModeDelete:
+			cfg.mode = mode
+		default:
+			return nil, fmt.Errorf("%s must be %s or %s, got %q", cleanerModeEnvName, ModeArchive, ModeDelete, raw)
+		}
+	}
+
+	if raw := os.Getenv(cleanerRetentionEnvName); raw != "" {
+		retention, err := parseRetention(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cleanerRetentionEnvName, err)
+		}
+		cfg.policy.Default = retention
+	}
+
+	if raw := os.Getenv(cleanerTrashRetentionEnvName); raw != "" {
+		retention, err := parseRetention(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cleanerTrashRetentionEnvName, err)
+		}
+		cfg.trash = retention
+	}
+
+	projects, err := parseOverrides(cleanerRetentionProjectEnvName, model.NormalizeProjectKey)
+	if err != nil {
+		return nil, err
+	}
+	cfg.policy.Project = projects
+
+	tags, err := parseOverrides(cleanerRetentionTagEnvName, model.NormalizeTag)
+	if err != nil {
+		return nil, err
+	}
+	cfg.policy.Tag = tags
+
+	return cfg, nil
+}
+
+func (c *cleanerConfig) Interval() time.Duration {
+	return c.interval
+}
+
+func (c *cleanerConfig) Mode() Mode {
+	return c.mode
+}
+
+func (c *cleanerConfig) Policy() model.RetentionPolicy {
+	return c.policy
+}
+
+func (c *cleanerConfig) TrashRetention() time.Duration {
+	return c.trash
+}
+
+func parseRetention(raw string) (time.Duration, error) {
+	if strings.EqualFold(strings.TrimSpace(raw), keepForever) {
+		return model.KeepForever, nil
+	}
+	return duedate.ParseDuration(raw)
+}
+
+func parseOverrides(envName string, normalize func(string) (string, error)) (map[string]time.Duration, error) {
+	overrides := make(map[string]time.Duration)
+	for _, pair := range strings.Split(os.Getenv(envName), ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s entries must look like name=retention, got %q", envName, pair)
+		}
+		key, err := normalize(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", envName, err)
+		}
+		retention, err := parseRetention(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", envName, err)
+		}
+		overrides[key] = retention
+	}
+	return overrides, nil
+}
@@ -1,10 +1,20 @@
 package config
 
-import "github.com/joho/godotenv"
+import (
+	"errors"
+	"os"
 
+	"github.com/joho/godotenv"
+)
+
+// Load reads environment variables from path. A missing file is not an
+// error, so the apps can also be configured from the real environment.
 func Load(path string) error {
 	err := godotenv.Load(path)
 	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
 		return err
 	}
 
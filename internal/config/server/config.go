@@ -0,0 +1,87 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"os"
+)
+
+const (
+	serverHostEnvName = "SERVER_HOST"
+	serverPortEnvName = "SERVER_PORT"
+)
+
+type HTTPConfig interface {
+	Host() string
+	Port() string
+	Address() string
+}
+
+type httpConfig struct {
+	host string
+	port string
+}
+
+func NewHTTPConfig() (HTTPConfig, error) {
+	host := os.Getenv(serverHostEnvName)
+	if len(host) == 0 {
+		host = "0.0.0.0"
+	}
+
+	port := os.Getenv(serverPortEnvName)
+	if len(port) == 0 {
+		return nil, errors.New("server port not found")
+	}
+
+	return &httpConfig{
+		host: host,
+		port: port,
+	}, nil
+}
+
+func (c *httpConfig) Host() string {
+	return c.host
+}
+
+func (c *httpConfig) Port() string {
+	return c.port
+}
+
+func (c *httpConfig) Address() string {
+	return net.JoinHostPort(c.host, c.port)
+}
+
+const (
+	grpcHostEnvName = "GRPC_HOST"
+	grpcPortEnvName = "GRPC_PORT"
+)
+
+type GRPCConfig interface {
+	Address() string
+}
+
+type grpcConfig struct {
+	host string
+	port string
+}
+
+func NewGRPCConfig() (GRPCConfig, error) {
+	host := os.Getenv(grpcHostEnvName)
+	if len(host) == 0 {
+		host = "0.0.0.0"
+	}
+
+	port := os.Getenv(grpcPortEnvName)
+	if len(port) == 0 {
+		return nil, errors.New("grpc port not found")
+	}
+
+	return &grpcConfig{
+		host: host,
+		port: port,
+	}, nil
+}
+
+func (c *grpcConfig) Address() string {
+	return net.JoinHostPort(c.host, c.port)
+}
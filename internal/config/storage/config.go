@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	storageDriverEnvName = "STORAGE_DRIVER"
+	sqlitePathEnvName    = "SQLITE_PATH"
+)
+
+const (
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory"
+	DriverSQLite   = "sqlite"
+)
+
+type StorageConfig interface {
+	Driver() string
+	SQLitePath() string
+}
+
+type storageConfig struct {
+	driver     string
+	sqlitePath string
+}
+
+func NewStorageConfig() (StorageConfig, error) {
+	driver := os.Getenv(storageDriverEnvName)
+	if len(driver) == 0 {
+		driver = DriverPostgres
+	}
+
+	switch driver {
+	case DriverPostgres, DriverMemory, DriverSQLite:
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q (expected %s, %s or %s)", driver, DriverPostgres, DriverMemory, DriverSQLite)
+	}
+
+	sqlitePath := os.Getenv(sqlitePathEnvName)
+	if len(sqlitePath) == 0 {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			dir = "."
+		}
+		sqlitePath = filepath.Join(dir, "taskmanager", "tasks.db")
+	}
+
+	return &storageConfig{
+		driver:     driver,
+		sqlitePath: sqlitePath,
+	}, nil
+}
+
+func (c *storageConfig) Driver() string {
+	return c.driver
+}
+
+func (c *storageConfig) SQLitePath() string {
+	return c.sqlitePath
+}
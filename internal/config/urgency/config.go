@@ -0,0 +1,112 @@
+package urgency
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"techno/internal/model"
+	"time"
+)
+
+const (
+	urgencyPriorityEnvName = "URGENCY_PRIORITY"
+	urgencyDueEnvName      = "URGENCY_DUE"
+	urgencyAgeEnvName      = "URGENCY_AGE"
+	urgencyAgeMaxEnvName   = "URGENCY_AGE_MAX_DAYS"
+	urgencyTagsEnvName     = "URGENCY_TAGS"
+	urgencyBlockedEnvName  = "URGENCY_BLOCKED"
+	urgencyTagEnvName      = "URGENCY_TAG"
+)
+
+type UrgencyConfig interface {
+	Weights() model.UrgencyWeights
+}
+
+type urgencyConfig struct {
+	weights model.UrgencyWeights
+}
+
+// NewUrgencyConfig reads the coefficients of `task next`. Unset variables
+// keep the defaults, URGENCY_TAG takes "tag=coefficient" pairs separated
+// by commas.
+func NewUrgencyConfig() (UrgencyConfig, error) {
+	weights := model.UrgencyWeights{
+		Priority: 6,
+		Due:      12,
+		Age:      2,
+		AgeMax:   365 * 24 * time.Hour,
+		Tags:     1,
+		Blocked:  -5,
+	}
+
+	for name, dst := range map[string]*float64{
+		urgencyPriorityEnvName: &weights.Priority,
+		urgencyDueEnvName:      &weights.Due,
+		urgencyAgeEnvName:      &weights.Age,
+		urgencyTagsEnvName:     &weights.Tags,
+		urgencyBlockedEnvName:  &weights.Blocked,
+	} {
+		if err := parseFloatEnv(name, dst); err != nil {
+			return nil, err
+		}
+	}
+
+	if raw := os.Getenv(urgencyAgeMaxEnvName); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil || days <= 0 {
+			return nil, fmt.Errorf("%s must be a positive number of days, got %q", urgencyAgeMaxEnvName, raw)
+		}
+		weights.AgeMax = time.Duration(days) * 24 * time.Hour
+	}
+
+	tags, err := parseTagWeights(os.Getenv(urgencyTagEnvName))
+	if err != nil {
+		return nil, err
+	}
+	weights.Tag = tags
+
+	return &urgencyConfig{weights: weights}, nil
+}
+
+func (c *urgencyConfig) Weights() model.UrgencyWeights {
+	return c.weights
+}
+
+func parseFloatEnv(name string, dst *float64) error {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("%s must be a number, got %q", name, raw)
+	}
+	*dst = v
+	return nil
+}
+
+func parseTagWeights(raw string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s entries must look like tag=coefficient, got %q", urgencyTagEnvName, pair)
+		}
+		tag, err := model.NormalizeTag(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", urgencyTagEnvName, err)
+		}
+		coefficient, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: coefficient of %q must be a number, got %q", urgencyTagEnvName, tag, value)
+		}
+		weights[tag] = coefficient
+	}
+	return weights, nil
+}
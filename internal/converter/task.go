@@ -0,0 +1,137 @@
+package converter
+
+import (
+	"fmt"
+	"techno/internal/model"
+	desc "techno/pkg/task_v1"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ToTaskFromService(task *model.Task) *desc.Task {
+	return &desc.Task{
+		Id:          int64(task.ID),
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      ToStatusFromService(task.Status),
+		CreatedAt:   timestamppb.New(task.CreatedAt),
+		UpdatedAt:   timestamppb.New(task.UpdatedAt),
+		CompletedAt: toTimestamp(task.CompletedAt),
+		DueAt:       toTimestamp(task.DueAt),
+		Overdue:     task.IsOverdue(time.Now()),
+		Priority:    desc.TaskPriority(task.Priority),
+		Tags:        task.Tags,
+		Project:     task.ProjectKey,
+		DisplayId:   task.DisplayID(),
+		ParentId:    toInt64(task.ParentID),
+
+		OpenDependencies: int32(task.OpenDependencies),
+	}
+}
+
+func ToStatusFromService(status model.TaskStatus) desc.TaskStatus {
+	switch status {
+	case model.Open:
+		return desc.TaskStatus_TASK_STATUS_OPEN
+	case model.Closed:
+		return desc.TaskStatus_TASK_STATUS_CLOSED
+	case model.InProgress:
+		return desc.TaskStatus_TASK_STATUS_IN_PROGRESS
+	case model.Blocked:
+		return desc.TaskStatus_TASK_STATUS_BLOCKED
+	case model.Cancelled:
+		return desc.TaskStatus_TASK_STATUS_CANCELLED
+	default:
+		return desc.TaskStatus_TASK_STATUS_UNSPECIFIED
+	}
+}
+
+func ToStatusFromDesc(status desc.TaskStatus) (model.TaskStatus, error) {
+	switch status {
+	case desc.TaskStatus_TASK_STATUS_OPEN:
+		return model.Open, nil
+	case desc.TaskStatus_TASK_STATUS_CLOSED:
+		return model.Closed, nil
+	case desc.TaskStatus_TASK_STATUS_IN_PROGRESS:
+		return model.InProgress, nil
+	case desc.TaskStatus_TASK_STATUS_BLOCKED:
+		return model.Blocked, nil
+	case desc.TaskStatus_TASK_STATUS_CANCELLED:
+		return model.Cancelled, nil
+	default:
+		return 0, fmt.Errorf("%w: unsupported task status %s", model.ErrInvalidInput, status)
+	}
+}
+
+func ToTaskFromCreateRequest(req *desc.CreateTaskRequest) *model.Task {
+	return &model.Task{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		DueAt:       fromTimestamp(req.GetDueAt()),
+		Priority:    model.TaskPriority(req.GetPriority()),
+		Tags:        req.GetTags(),
+		ProjectKey:  req.GetProject(),
+		ParentID:    FromID(req.GetParentId()),
+	}
+}
+
+func ToFilterFromListRequest(req *desc.ListTasksRequest) (model.TaskFilter, error) {
+	filter := model.TaskFilter{
+		Overdue:   req.GetOverdue(),
+		DueBefore: fromTimestamp(req.GetDueBefore()),
+		DueAfter:  fromTimestamp(req.GetDueAfter()),
+		Tags:      req.GetTags(),
+		AllTags:   req.GetAllTags(),
+		Project:   req.GetProject(),
+	}
+
+	if req.GetStatus() != desc.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		status, err := ToStatusFromDesc(req.GetStatus())
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = &status
+	}
+
+	if req.GetSort() != "" {
+		keys, err := model.ParseSort(req.GetSort())
+		if err != nil {
+			return filter, err
+		}
+		filter.Sort = keys
+	}
+
+	return filter, nil
+}
+
+// FromID maps the zero id proto uses for "none" to nil.
+func FromID(id int64) *int {
+	if id == 0 {
+		return nil
+	}
+	v := int(id)
+	return &v
+}
+
+func toInt64(id *int) int64 {
+	if id == nil {
+		return 0
+	}
+	return int64(*id)
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
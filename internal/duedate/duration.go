@@ -0,0 +1,48 @@
+package duedate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"techno/internal/model"
+	"time"
+)
+
+// ParseDuration extends time.ParseDuration with days and weeks: "14d",
+// "2w", "1d12h". Weeks and days go first.
+func ParseDuration(input string) (time.Duration, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	if s == "" {
+		return 0, invalidDuration(input)
+	}
+
+	var total time.Duration
+	for _, unit := range []struct {
+		suffix string
+		size   time.Duration
+	}{{"w", 7 * 24 * time.Hour}, {"d", 24 * time.Hour}} {
+		head, rest, found := strings.Cut(s, unit.suffix)
+		if !found {
+			continue
+		}
+		n, err := strconv.Atoi(head)
+		if err != nil || n < 0 {
+			return 0, invalidDuration(input)
+		}
+		total += time.Duration(n) * unit.size
+		s = rest
+	}
+
+	if s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d < 0 {
+			return 0, invalidDuration(input)
+		}
+		total += d
+	}
+	return total, nil
+}
+
+func invalidDuration(input string) error {
+	return fmt.Errorf("%w: cannot parse duration %q, use e.g. 30m, 12h, 14d or 2w", model.ErrInvalidInput, input)
+}
@@ -0,0 +1,221 @@
+// Package duedate turns what people type into --due flags into absolute
+// times. Besides ISO-like timestamps it understands short English and
+// Russian expressions such as "tomorrow 17:00", "next friday", "in 3 days",
+// "eow", "завтра" or "через 2 дня".
+package duedate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"techno/internal/model"
+	"time"
+)
+
+var layouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// Parse resolves input relative to now, in now's location.
+//
+// Expressions naming a day ("tomorrow", "friday", "завтра") resolve to the
+// start of that day unless a clock time is given. Relative offsets ("in 3
+// days", "через 2 часа") keep the current clock time. Weekdays always mean
+// the nearest such day after today.
+func Parse(input string, now time.Time) (time.Time, error) {
+	trimmed := strings.TrimSpace(input)
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, trimmed, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	tokens := strings.Fields(strings.ToLower(trimmed))
+	hour, minute, tokens, hasClock := splitClock(tokens)
+	if len(tokens) > 0 && isClockPreposition(tokens[len(tokens)-1]) {
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	t, exact, ok := resolve(tokens, now)
+	if !ok || (len(tokens) == 0 && !hasClock) {
+		return time.Time{}, fmt.Errorf(
+			"%w: cannot parse time %q, use YYYY-MM-DD [HH:MM] or an expression like \"tomorrow 17:00\", \"next friday\", \"in 3 days\", \"eow\", \"завтра\"",
+			model.ErrInvalidInput, input)
+	}
+
+	switch {
+	case hasClock:
+		t = time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location())
+	case !exact:
+		t = startOfDay(t)
+	}
+	return t, nil
+}
+
+// resolve maps the date part of an expression to a time. exact reports
+// whether the result already carries a meaningful clock time.
+func resolve(tokens []string, now time.Time) (t time.Time, exact bool, ok bool) {
+	phrase := strings.Join(tokens, " ")
+
+	if phrase == "" {
+		return now, false, true
+	}
+	if offset, found := dayWords[phrase]; found {
+		return now.AddDate(0, 0, offset), false, true
+	}
+	if end, found := endWords[phrase]; found {
+		return end(now), true, true
+	}
+	if period, found := nextPeriodWords[phrase]; found {
+		return period(now), false, true
+	}
+	if t, found := relative(tokens, now); found {
+		return t, true, true
+	}
+	if day, found := weekday(tokens); found {
+		return nextWeekday(now, day), false, true
+	}
+	return time.Time{}, false, false
+}
+
+// relative handles "in 3 days", "in a week", "через 2 дня", "через час".
+func relative(tokens []string, now time.Time) (time.Time, bool) {
+	if len(tokens) < 2 || (tokens[0] != "in" && tokens[0] != "через") {
+		return time.Time{}, false
+	}
+
+	n := 1
+	rest := tokens[1:]
+	if len(rest) == 2 {
+		switch rest[0] {
+		case "a", "an", "one", "один", "одну", "одна":
+		default:
+			parsed, err := strconv.Atoi(rest[0])
+			if err != nil || parsed < 0 {
+				return time.Time{}, false
+			}
+			n = parsed
+		}
+		rest = rest[1:]
+	}
+	if len(rest) != 1 {
+		return time.Time{}, false
+	}
+
+	unit, found := units[rest[0]]
+	if !found {
+		return time.Time{}, false
+	}
+	return unit(now, n), true
+}
+
+// weekday handles "friday", "next friday", "on fri", "в пятницу",
+// "в следующую пятницу".
+func weekday(tokens []string) (time.Weekday, bool) {
+	for len(tokens) > 1 && weekdayPrefixes[tokens[0]] {
+		tokens = tokens[1:]
+	}
+	if len(tokens) != 1 {
+		return 0, false
+	}
+	day, found := weekdays[tokens[0]]
+	return day, found
+}
+
+// splitClock removes a trailing clock time ("17:00", "5pm", "5:30 pm") from
+// tokens.
+func splitClock(tokens []string) (hour, minute int, rest []string, ok bool) {
+	if len(tokens) == 0 {
+		return 0, 0, tokens, false
+	}
+
+	last := tokens[len(tokens)-1]
+	rest = tokens[:len(tokens)-1]
+	if (last == "am" || last == "pm") && len(rest) > 0 {
+		last = rest[len(rest)-1] + last
+		rest = rest[:len(rest)-1]
+	}
+
+	hour, minute, ok = parseClock(last)
+	if !ok {
+		return 0, 0, tokens, false
+	}
+	return hour, minute, rest, true
+}
+
+func parseClock(s string) (hour, minute int, ok bool) {
+	meridiem := ""
+	if strings.HasSuffix(s, "am") || strings.HasSuffix(s, "pm") {
+		meridiem = s[len(s)-2:]
+		s = s[:len(s)-2]
+	}
+
+	hourStr, minuteStr, hasColon := strings.Cut(s, ":")
+	if !hasColon && meridiem == "" {
+		return 0, 0, false
+	}
+
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	if hasColon {
+		if len(minuteStr) != 2 {
+			return 0, 0, false
+		}
+		if minute, err = strconv.Atoi(minuteStr); err != nil || minute > 59 {
+			return 0, 0, false
+		}
+	}
+
+	switch meridiem {
+	case "":
+		if hour > 23 {
+			return 0, 0, false
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+	return hour, minute, true
+}
+
+func isClockPreposition(token string) bool {
+	return token == "at" || token == "@" || token == "в" || token == "к"
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 0, 0, t.Location())
+}
+
+// endOfWeek is Sunday evening, weeks start on Monday.
+func endOfWeek(t time.Time) time.Time {
+	daysLeft := (7 - int(t.Weekday())) % 7
+	return endOfDay(t.AddDate(0, 0, daysLeft))
+}
+
+func endOfMonth(t time.Time) time.Time {
+	firstOfNext := time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+	return endOfDay(firstOfNext.AddDate(0, 0, -1))
+}
+
+func nextWeekday(now time.Time, day time.Weekday) time.Time {
+	days := (int(day) - int(now.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return now.AddDate(0, 0, days)
+}
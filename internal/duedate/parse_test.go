@@ -0,0 +1,154 @@
+package duedate
+
+import (
+	"errors"
+	"techno/internal/model"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2026, time.October, 14, 10, 30, 0, 0, time.UTC)
+	// Sunday.
+	sunday := time.Date(2026, time.October, 18, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		input string
+		now   time.Time
+		want  time.Time
+	}{
+		{"date", "2026-11-01", now, time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)},
+		{"date and time", "2026-11-01 17:45", now, time.Date(2026, time.November, 1, 17, 45, 0, 0, time.UTC)},
+		{"rfc3339", "2026-11-01T17:45:00Z", now, time.Date(2026, time.November, 1, 17, 45, 0, 0, time.UTC)},
+		{"today", "today", now, time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)},
+		{"tomorrow", "Tomorrow", now, time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{"tomorrow with clock", "tomorrow 17:00", now, time.Date(2026, time.October, 15, 17, 0, 0, 0, time.UTC)},
+		{"tomorrow at pm", "tomorrow at 5pm", now, time.Date(2026, time.October, 15, 17, 0, 0, 0, time.UTC)},
+		{"separate meridiem", "tomorrow 5:30 pm", now, time.Date(2026, time.October, 15, 17, 30, 0, 0, time.UTC)},
+		{"midnight am", "tomorrow 12am", now, time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{"clock only", "18:00", now, time.Date(2026, time.October, 14, 18, 0, 0, 0, time.UTC)},
+		{"day after tomorrow", "day after tomorrow", now, time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)},
+		{"eod", "eod", now, time.Date(2026, time.October, 14, 23, 59, 0, 0, time.UTC)},
+		{"eow", "eow", now, time.Date(2026, time.October, 18, 23, 59, 0, 0, time.UTC)},
+		{"eow on sunday", "eow", sunday, time.Date(2026, time.October, 18, 23, 59, 0, 0, time.UTC)},
+		{"eom", "end of month", now, time.Date(2026, time.October, 31, 23, 59, 0, 0, time.UTC)},
+		{"next week", "next week", now, time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)},
+		{"next month", "next month", now, time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)},
+		{"weekday", "friday", now, time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)},
+		{"next weekday", "next fri 9:00", now, time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)},
+		{"same weekday", "wednesday", now, time.Date(2026, time.October, 21, 0, 0, 0, 0, time.UTC)},
+		{"monday from sunday", "mon", sunday, time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)},
+		{"in days", "in 3 days", now, time.Date(2026, time.October, 17, 10, 30, 0, 0, time.UTC)},
+		{"in a week", "in a week", now, time.Date(2026, time.October, 21, 10, 30, 0, 0, time.UTC)},
+		{"in hours", "in 2 hours", now, time.Date(2026, time.October, 14, 12, 30, 0, 0, time.UTC)},
+		{"in months", "in 2 months", now, time.Date(2026, time.December, 14, 10, 30, 0, 0, time.UTC)},
+		{"сегодня", "сегодня", now, time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)},
+		{"завтра", "завтра", now, time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{"завтра в", "завтра в 9:15", now, time.Date(2026, time.October, 15, 9, 15, 0, 0, time.UTC)},
+		{"послезавтра", "послезавтра", now, time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)},
+		{"к концу недели", "к концу недели", now, time.Date(2026, time.October, 18, 23, 59, 0, 0, time.UTC)},
+		{"конец месяца", "конец месяца", now, time.Date(2026, time.October, 31, 23, 59, 0, 0, time.UTC)},
+		{"в пятницу", "в пятницу", now, time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)},
+		{"в следующую среду", "в следующую среду", now, time.Date(2026, time.October, 21, 0, 0, 0, 0, time.UTC)},
+		{"через дня", "через 2 дня", now, time.Date(2026, time.October, 16, 10, 30, 0, 0, time.UTC)},
+		{"через час", "через час", now, time.Date(2026, time.October, 14, 11, 30, 0, 0, time.UTC)},
+		{"через неделю", "через неделю", now, time.Date(2026, time.October, 21, 10, 30, 0, 0, time.UTC)},
+		{"на следующей неделе", "на следующей неделе", now, time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input, tt.now)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseKeepsLocation(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	now := time.Date(2026, time.October, 14, 23, 30, 0, 0, moscow)
+
+	got, err := Parse("tomorrow 9:00", now)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	want := time.Date(2026, time.October, 15, 9, 0, 0, 0, moscow)
+	if !got.Equal(want) || got.Location() != moscow {
+		t.Errorf("Parse = %v, want %v", got, want)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	now := time.Date(2026, time.October, 14, 10, 30, 0, 0, time.UTC)
+
+	tests := []string{
+		"",
+		"   ",
+		"someday",
+		"at",
+		"tomorrow 25:00",
+		"tomorrow 13pm",
+		"tomorrow 9:5",
+		"in -1 days",
+		"in 3 fortnights",
+		"через много дней",
+		"next",
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			_, err := Parse(input, now)
+			if !errors.Is(err, model.ErrInvalidInput) {
+				t.Errorf("Parse(%q) error = %v, want ErrInvalidInput", input, err)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "30m", want: 30 * time.Minute},
+		{input: "12h", want: 12 * time.Hour},
+		{input: "14d", want: 14 * 24 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: "1d12h", want: 36 * time.Hour},
+		{input: "1w2d", want: 9 * 24 * time.Hour},
+		{input: " 3D ", want: 3 * 24 * time.Hour},
+		{input: "0d", want: 0},
+		{input: "", wantErr: true},
+		{input: "d", wantErr: true},
+		{input: "-1d", wantErr: true},
+		{input: "-5m", wantErr: true},
+		{input: "2d1w", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, model.ErrInvalidInput) {
+					t.Errorf("ParseDuration(%q) error = %v, want ErrInvalidInput", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDuration(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package duedate
+
+import "time"
+
+// dayWords are offsets in days from today.
+var dayWords = map[string]int{
+	"today":              0,
+	"tomorrow":           1,
+	"day after tomorrow": 2,
+	"сегодня":            0,
+	"завтра":             1,
+	"послезавтра":        2,
+}
+
+var endWords = map[string]func(time.Time) time.Time{
+	"eod":            endOfDay,
+	"end of day":     endOfDay,
+	"конец дня":      endOfDay,
+	"к концу дня":    endOfDay,
+	"eow":            endOfWeek,
+	"end of week":    endOfWeek,
+	"конец недели":   endOfWeek,
+	"к концу недели": endOfWeek,
+	"eom":            endOfMonth,
+	"end of month":   endOfMonth,
+	"конец месяца":   endOfMonth,
+	"к концу месяца": endOfMonth,
+}
+
+var nextPeriodWords = map[string]func(time.Time) time.Time{
+	"next week":           nextMonday,
+	"на следующей неделе": nextMonday,
+	"следующая неделя":    nextMonday,
+	"next month":          firstOfNextMonth,
+	"в следующем месяце":  firstOfNextMonth,
+	"следующий месяц":     firstOfNextMonth,
+}
+
+var units = map[string]func(time.Time, int) time.Time{}
+
+func init() {
+	register := func(add func(time.Time, int) time.Time, names ...string) {
+		for _, name := range names {
+			units[name] = add
+		}
+	}
+
+	register(func(t time.Time, n int) time.Time { return t.Add(time.Duration(n) * time.Minute) },
+		"min", "mins", "minute", "minutes", "минуту", "минуты", "минут")
+	register(func(t time.Time, n int) time.Time { return t.Add(time.Duration(n) * time.Hour) },
+		"h", "hour", "hours", "час", "часа", "часов")
+	register(func(t time.Time, n int) time.Time { return t.AddDate(0, 0, n) },
+		"d", "day", "days", "день", "дня", "дней")
+	register(func(t time.Time, n int) time.Time { return t.AddDate(0, 0, 7*n) },
+		"w", "week", "weeks", "неделю", "недели", "недель")
+	register(func(t time.Time, n int) time.Time { return t.AddDate(0, n, 0) },
+		"month", "months", "месяц", "месяца", "месяцев")
+}
+
+var weekdays = map[string]time.Weekday{
+	"monday":      time.Monday,
+	"mon":         time.Monday,
+	"tuesday":     time.Tuesday,
+	"tue":         time.Tuesday,
+	"wednesday":   time.Wednesday,
+	"wed":         time.Wednesday,
+	"thursday":    time.Thursday,
+	"thu":         time.Thursday,
+	"friday":      time.Friday,
+	"fri":         time.Friday,
+	"saturday":    time.Saturday,
+	"sat":         time.Saturday,
+	"sunday":      time.Sunday,
+	"sun":         time.Sunday,
+	"понедельник": time.Monday,
+	"пн":          time.Monday,
+	"вторник":     time.Tuesday,
+	"вт":          time.Tuesday,
+	"среда":       time.Wednesday,
+	"среду":       time.Wednesday,
+	"ср":          time.Wednesday,
+	"четверг":     time.Thursday,
+	"чт":          time.Thursday,
+	"пятница":     time.Friday,
+	"пятницу":     time.Friday,
+	"пт":          time.Friday,
+	"суббота":     time.Saturday,
+	"субботу":     time.Saturday,
+	"сб":          time.Saturday,
+	"воскресенье": time.Sunday,
+	"вс":          time.Sunday,
+}
+
+// weekdayPrefixes may precede a weekday name without changing its meaning.
+var weekdayPrefixes = map[string]bool{
+	"next":      true,
+	"this":      true,
+	"on":        true,
+	"в":         true,
+	"во":        true,
+	"следующий": true,
+	"следующую": true,
+	"следующее": true,
+	"ближайший": true,
+	"ближайшую": true,
+}
+
+func nextMonday(now time.Time) time.Time {
+	return nextWeekday(now, time.Monday)
+}
+
+func firstOfNextMonth(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
+}
@@ -0,0 +1,99 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"techno/internal/config/storage"
+	"techno/migrations"
+
+	"github.com/pressly/goose/v3"
+)
+
+var ErrSchemaOutdated = errors.New("database schema is outdated")
+
+type Migrator struct {
+	provider *goose.Provider
+}
+
+func New(driver string, db *sql.DB) (*Migrator, error) {
+	var dialect goose.Dialect
+	var fsys fs.FS
+
+	switch driver {
+	case storage.DriverPostgres:
+		dialect, fsys = goose.DialectPostgres, migrations.Postgres()
+	case storage.DriverSQLite:
+		dialect, fsys = goose.DialectSQLite3, migrations.SQLite()
+	default:
+		return nil, fmt.Errorf("storage driver %q has no migrations", driver)
+	}
+
+	provider, err := goose.NewProvider(dialect, db, fsys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init migrations: %w", err)
+	}
+
+	return &Migrator{
+		provider: provider,
+	}, nil
+}
+
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	return m.provider.Up(ctx)
+}
+
+func (m *Migrator) Down(ctx context.Context) (*goose.MigrationResult, error) {
+	return m.provider.Down(ctx)
+}
+
+// Redo rolls back the latest applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) ([]*goose.MigrationResult, error) {
+	down, err := m.provider.Down(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	up, err := m.provider.UpByOne(ctx)
+	if err != nil {
+		return []*goose.MigrationResult{down}, err
+	}
+
+	return []*goose.MigrationResult{down, up}, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	return m.provider.Status(ctx)
+}
+
+// Versions returns the version the database is at and the latest version
+// embedded into the binary.
+func (m *Migrator) Versions(ctx context.Context) (current, latest int64, err error) {
+	current, err = m.provider.GetDBVersion(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	sources := m.provider.ListSources()
+	if len(sources) > 0 {
+		latest = sources[len(sources)-1].Version
+	}
+
+	return current, latest, nil
+}
+
+// CheckVersion refuses to work with a database that has not been migrated
+// to the schema this binary was built for.
+func (m *Migrator) CheckVersion(ctx context.Context) error {
+	current, latest, err := m.Versions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if current < latest {
+		return fmt.Errorf("%w: database is at version %d, this binary requires %d; run `taskmanager migrate up`", ErrSchemaOutdated, current, latest)
+	}
+	return nil
+}
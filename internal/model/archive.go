@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// ArchivedTask is a finished task the cleaner moved to the archive. It
+// keeps every field of the task, OpenDependencies stays zero because
+// dependencies are not archived.
+type ArchivedTask struct {
+	Task
+	ArchivedAt time.Time
+}
@@ -0,0 +1,78 @@
+package model
+
+import "fmt"
+
+// DependencyGraph maps a task id to the ids of the tasks it depends on.
+type DependencyGraph map[int][]int
+
+// Path returns the chain of dependencies leading from one task to another,
+// both included, or nil when to is not reachable from from.
+func (g DependencyGraph) Path(from, to int) []int {
+	prev := map[int]int{from: from}
+	for queue := []int{from}; len(queue) > 0; queue = queue[1:] {
+		current := queue[0]
+		if current == to {
+			var path []int
+			for id := to; id != from; id = prev[id] {
+				path = append([]int{id}, path...)
+			}
+			return append([]int{from}, path...)
+		}
+		for _, next := range g[current] {
+			if _, seen := prev[next]; !seen {
+				prev[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+// CycleError is returned for a dependency that would close a cycle. Path is
+// the chain of task ids from the dependent task back to itself.
+type CycleError struct {
+	Path []int
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%v: dependency would create a cycle %v", ErrInvalidInput, e.Path)
+}
+
+func (e *CycleError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// CheckDependency tells whether taskID may start depending on dependsOnID.
+func (g DependencyGraph) CheckDependency(taskID, dependsOnID int) error {
+	for _, id := range g[taskID] {
+		if id == dependsOnID {
+			return fmt.Errorf("dependency of task %d on %d %w", taskID, dependsOnID, ErrAlreadyExists)
+		}
+	}
+	// The new edge closes a cycle when the other task already waits for
+	// this one, directly or through other tasks.
+	if path := g.Path(dependsOnID, taskID); path != nil {
+		return &CycleError{Path: append([]int{taskID}, path...)}
+	}
+	return nil
+}
+
+// Dependents returns the ids of the tasks that depend on id directly.
+func (g DependencyGraph) Dependents(id int) []int {
+	var dependents []int
+	for taskID, deps := range g {
+		for _, dep := range deps {
+			if dep == id {
+				dependents = append(dependents, taskID)
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// IsWaiting reports whether some of the tasks this one depends on are not
+// finished yet.
+func (t *Task) IsWaiting() bool {
+	return t.OpenDependencies > 0 && !t.Status.IsFinished()
+}
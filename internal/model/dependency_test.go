@@ -0,0 +1,82 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestDependencyGraphPath(t *testing.T) {
+	// Add never stores a self loop or a cycle, Path must still stop on them.
+	graph := DependencyGraph{
+		1: {2, 5},
+		2: {3},
+		3: {4},
+		5: {4},
+		6: {6},
+		7: {8},
+		8: {7},
+	}
+
+	tests := []struct {
+		name     string
+		from, to int
+		want     []int
+	}{
+		{"same task", 1, 1, []int{1}},
+		{"direct", 2, 3, []int{2, 3}},
+		{"transitive", 2, 4, []int{2, 3, 4}},
+		{"shortest of two", 1, 4, []int{1, 5, 4}},
+		{"against direction", 4, 1, nil},
+		{"unknown task", 9, 1, nil},
+		{"self loop", 6, 1, nil},
+		{"through a cycle", 7, 8, []int{7, 8}},
+		{"around a cycle", 8, 7, []int{8, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := graph.Path(tt.from, tt.to)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Path(%d, %d) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependencyGraphCheckDependency(t *testing.T) {
+	graph := DependencyGraph{
+		1: {2},
+		2: {3},
+	}
+
+	tests := []struct {
+		name              string
+		taskID, dependsOn int
+		wantErr           error
+		wantCycle         []int
+	}{
+		{name: "new edge", taskID: 1, dependsOn: 3},
+		{name: "unrelated", taskID: 4, dependsOn: 1},
+		{name: "existing edge", taskID: 1, dependsOn: 2, wantErr: ErrAlreadyExists},
+		{name: "direct cycle", taskID: 2, dependsOn: 1, wantErr: ErrInvalidInput, wantCycle: []int{2, 1, 2}},
+		{name: "long cycle", taskID: 3, dependsOn: 1, wantErr: ErrInvalidInput, wantCycle: []int{3, 1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := graph.CheckDependency(tt.taskID, tt.dependsOn)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("CheckDependency(%d, %d) error = %v, want %v", tt.taskID, tt.dependsOn, err, tt.wantErr)
+			}
+
+			var cycle *CycleError
+			if errors.As(err, &cycle) != (tt.wantCycle != nil) {
+				t.Fatalf("CheckDependency(%d, %d) error = %v, want cycle %v", tt.taskID, tt.dependsOn, err, tt.wantCycle)
+			}
+			if cycle != nil && !slices.Equal(cycle.Path, tt.wantCycle) {
+				t.Errorf("cycle path = %v, want %v", cycle.Path, tt.wantCycle)
+			}
+		})
+	}
+}
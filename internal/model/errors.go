@@ -0,0 +1,14 @@
+package model
+
+import "errors"
+
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrInvalidTransition  = errors.New("invalid status transition")
+	ErrUnauthenticated    = errors.New("not authenticated")
+	ErrInvalidCredentials = errors.New("invalid login or password")
+	ErrHasSubtasks        = errors.New("task has subtasks")
+	ErrConflict           = errors.New("conflict")
+)
@@ -0,0 +1,248 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TaskEventKind is the change a task event records.
+type TaskEventKind string
+
+const (
+	TaskCreated  TaskEventKind = "create"
+	TaskUpdated  TaskEventKind = "update"
+	TaskDeleted  TaskEventKind = "delete"
+	TaskRestored TaskEventKind = "restore"
+	TaskArchived TaskEventKind = "archive"
+)
+
+// Fields a task event tracks.
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldStatus      = "status"
+	FieldPriority    = "priority"
+	FieldDue         = "due"
+	FieldTags        = "tags"
+	FieldProject     = "project"
+	FieldParent      = "parent"
+)
+
+// FieldChange is the old and the new value of a task field as text, an
+// empty value means the field was not set.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// TaskEvent is an audit record of a change to a task, written by the
+// repository in the transaction that makes the change.
+type TaskEvent struct {
+	ID      int
+	TaskID  int
+	OwnerID int
+	// ActorID is the user who made the change, nil for background jobs.
+	// Actor is the login of the user, "system" for background jobs.
+	ActorID   *int
+	Actor     string
+	Kind      TaskEventKind
+	Changes   []FieldChange
+	CreatedAt time.Time
+}
+
+// taskFields formats every tracked field of a task, in the order changes
+// are listed.
+var taskFields = []struct {
+	name  string
+	value func(*Task) string
+}{
+	{FieldTitle, func(t *Task) string { return t.Title }},
+	{FieldDescription, func(t *Task) string { return t.Description }},
+	{FieldStatus, func(t *Task) string { return t.Status.StringStatus() }},
+	{FieldPriority, func(t *Task) string { return t.Priority.StringPriority() }},
+	{FieldDue, func(t *Task) string {
+		if t.DueAt == nil {
+			return ""
+		}
+		return t.DueAt.UTC().Format(time.RFC3339Nano)
+	}},
+	{FieldTags, func(t *Task) string { return strings.Join(t.Tags, ",") }},
+	{FieldProject, func(t *Task) string {
+		if t.ProjectKey == "" {
+			return ""
+		}
+		return t.DisplayID()
+	}},
+	{FieldParent, func(t *Task) string {
+		if t.ParentID == nil {
+			return ""
+		}
+		return strconv.Itoa(*t.ParentID)
+	}},
+}
+
+// DiffTask lists the tracked fields that differ between two images of a
+// task. A nil image has every field unset, so a create lists every field
+// the new task has.
+func DiffTask(before, after *Task) []FieldChange {
+	var changes []FieldChange
+	for _, field := range taskFields {
+		var old, value string
+		if before != nil {
+			old = field.value(before)
+		}
+		if after != nil {
+			value = field.value(after)
+		}
+		if old != value {
+			changes = append(changes, FieldChange{Field: field.name, Old: old, New: value})
+		}
+	}
+	return changes
+}
+
+// NewTaskEvent builds the event of a change from the images of the task
+// before and after it. actorID is nil for background jobs.
+func NewTaskEvent(kind TaskEventKind, actorID *int, before, after *Task) *TaskEvent {
+	task := after
+	if task == nil {
+		task = before
+	}
+
+	event := &TaskEvent{
+		TaskID:  task.ID,
+		OwnerID: task.OwnerID,
+		ActorID: actorID,
+		Kind:    kind,
+	}
+	// A deleted or archived task keeps its fields, the event only says it
+	// left the task list.
+	if after != nil {
+		event.Changes = DiffTask(before, after)
+	}
+	return event
+}
+
+// ReplayEvents rebuilds the tasks that the events, in the order they
+// happened, leave in the task list, newest first. Events of a task
+// without a create event are skipped, the backfill migration records
+// one for every task that existed before it.
+func ReplayEvents(events []*TaskEvent) ([]*Task, error) {
+	states := make(map[int]*Task)
+	listed := make(map[int]bool)
+	for _, event := range events {
+		task, ok := states[event.TaskID]
+		if !ok {
+			if event.Kind != TaskCreated {
+				continue
+			}
+			task = &Task{ID: event.TaskID, OwnerID: event.OwnerID, CreatedAt: event.CreatedAt}
+			states[event.TaskID] = task
+		}
+
+		if err := event.apply(task); err != nil {
+			return nil, err
+		}
+		switch event.Kind {
+		case TaskCreated, TaskRestored:
+			listed[task.ID] = true
+		case TaskDeleted, TaskArchived:
+			delete(listed, task.ID)
+		}
+	}
+
+	tasks := make([]*Task, 0, len(listed))
+	for id := range listed {
+		tasks = append(tasks, states[id])
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+			return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+		}
+		return tasks[i].ID > tasks[j].ID
+	})
+	return tasks, nil
+}
+
+// apply sets the new values of the changed fields on a task, the way the
+// repository keeps UpdatedAt and CompletedAt.
+func (e *TaskEvent) apply(task *Task) error {
+	if len(e.Changes) == 0 {
+		return nil
+	}
+
+	for _, change := range e.Changes {
+		if err := applyChange(task, change); err != nil {
+			return fmt.Errorf("event %d of task %d: %w", e.ID, e.TaskID, err)
+		}
+	}
+
+	task.UpdatedAt = e.CreatedAt
+	switch {
+	case !task.Status.IsFinished():
+		task.CompletedAt = nil
+	case task.CompletedAt == nil:
+		completedAt := e.CreatedAt
+		task.CompletedAt = &completedAt
+	}
+	return nil
+}
+
+func applyChange(task *Task, change FieldChange) error {
+	value := change.New
+	switch change.Field {
+	case FieldTitle:
+		task.Title = value
+	case FieldDescription:
+		task.Description = value
+	case FieldStatus:
+		status, err := ParseTaskStatus(value)
+		if err != nil {
+			return err
+		}
+		task.Status = status
+	case FieldPriority:
+		priority, err := ParseTaskPriority(value)
+		if err != nil {
+			return err
+		}
+		task.Priority = priority
+	case FieldDue:
+		task.DueAt = nil
+		if value != "" {
+			due, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return fmt.Errorf("invalid due date %q: %w", value, err)
+			}
+			task.DueAt = &due
+		}
+	case FieldTags:
+		task.Tags = nil
+		if value != "" {
+			task.Tags = strings.Split(value, ",")
+		}
+	case FieldProject:
+		task.ProjectKey, task.Number = "", 0
+		if value != "" {
+			ref, err := ParseTaskRef(value)
+			if err != nil {
+				return err
+			}
+			task.ProjectKey, task.Number = ref.ProjectKey, ref.Number
+		}
+	case FieldParent:
+		task.ParentID = nil
+		if value != "" {
+			parentID, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid parent id %q: %w", value, err)
+			}
+			task.ParentID = &parentID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDiffTask(t *testing.T) {
+	due := time.Date(2026, time.November, 1, 17, 45, 0, 500, time.FixedZone("MSK", 3*60*60))
+	parentID := 7
+
+	tests := []struct {
+		name          string
+		before, after *Task
+		want          []FieldChange
+	}{
+		{
+			name:   "create",
+			before: nil,
+			after:  &Task{Title: "Deploy", Status: Open, Priority: PriorityHigh, DueAt: &due, Tags: []string{"a", "b"}, ProjectKey: "INFRA", Number: 12, ParentID: &parentID},
+			want: []FieldChange{
+				{Field: FieldTitle, New: "Deploy"},
+				{Field: FieldStatus, New: "not done"},
+				{Field: FieldPriority, New: "high"},
+				{Field: FieldDue, New: "2026-11-01T14:45:00.0000005Z"},
+				{Field: FieldTags, New: "a,b"},
+				{Field: FieldProject, New: "INFRA-12"},
+				{Field: FieldParent, New: "7"},
+			},
+		},
+		{
+			name:   "no change",
+			before: &Task{Title: "Deploy", Status: Open, Priority: PriorityMedium},
+			after:  &Task{Title: "Deploy", Status: Open, Priority: PriorityMedium},
+		},
+		{
+			name:   "status and priority",
+			before: &Task{Title: "Deploy", Status: Open, Priority: PriorityMedium},
+			after:  &Task{Title: "Deploy", Status: InProgress, Priority: PriorityCritical},
+			want: []FieldChange{
+				{Field: FieldStatus, Old: "not done", New: "in progress"},
+				{Field: FieldPriority, Old: "medium", New: "critical"},
+			},
+		},
+		{
+			name:   "fields cleared",
+			before: &Task{Title: "Deploy", Description: "prod", DueAt: &due, Tags: []string{"a"}, ProjectKey: "INFRA", Number: 1, ParentID: &parentID},
+			after:  &Task{Title: "Deploy"},
+			want: []FieldChange{
+				{Field: FieldDescription, Old: "prod"},
+				{Field: FieldDue, Old: "2026-11-01T14:45:00.0000005Z"},
+				{Field: FieldTags, Old: "a"},
+				{Field: FieldProject, Old: "INFRA-1"},
+				{Field: FieldParent, Old: "7"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffTask(tt.before, tt.after)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("DiffTask() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayEventsRoundTrip(t *testing.T) {
+	created := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	due := time.Date(2026, time.November, 1, 17, 45, 30, 250000000, time.UTC)
+	parentID := 3
+
+	base := Task{ID: 10, OwnerID: 1, Title: "Deploy", Status: Open, Priority: PriorityMedium}
+	with := func(change func(*Task)) *Task {
+		task := base
+		change(&task)
+		return &task
+	}
+
+	tests := []struct {
+		name  string
+		after *Task
+	}{
+		{"unchanged", with(func(*Task) {})},
+		{"description", with(func(t *Task) { t.Description = "with, comma" })},
+		{"in progress", with(func(t *Task) { t.Status = InProgress })},
+		{"blocked", with(func(t *Task) { t.Status = Blocked })},
+		{"done", with(func(t *Task) { t.Status = Closed })},
+		{"cancelled", with(func(t *Task) { t.Status = Cancelled })},
+		{"low", with(func(t *Task) { t.Priority = PriorityLow })},
+		{"high", with(func(t *Task) { t.Priority = PriorityHigh })},
+		{"critical", with(func(t *Task) { t.Priority = PriorityCritical })},
+		{"due", with(func(t *Task) { t.DueAt = &due })},
+		{"tags", with(func(t *Task) { t.Tags = []string{"backend", "infra"} })},
+		{"project", with(func(t *Task) { t.ProjectKey, t.Number = "INFRA", 12 })},
+		{"parent", with(func(t *Task) { t.ParentID = &parentID })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			create := NewTaskEvent(TaskCreated, nil, nil, &base)
+			create.CreatedAt = created
+			update := NewTaskEvent(TaskUpdated, nil, &base, tt.after)
+			update.CreatedAt = updated
+
+			tasks, err := ReplayEvents([]*TaskEvent{create, update})
+			if err != nil {
+				t.Fatalf("ReplayEvents error: %v", err)
+			}
+			if len(tasks) != 1 {
+				t.Fatalf("ReplayEvents returned %d tasks, want 1", len(tasks))
+			}
+			got := tasks[0]
+
+			if diff := DiffTask(tt.after, got); diff != nil {
+				t.Errorf("replayed task differs: %+v", diff)
+			}
+			if got.Status != tt.after.Status || got.Priority != tt.after.Priority {
+				t.Errorf("status, priority = %d, %d, want %d, %d", got.Status, got.Priority, tt.after.Status, tt.after.Priority)
+			}
+			if !got.CreatedAt.Equal(created) {
+				t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, created)
+			}
+			wantUpdated := created
+			if len(update.Changes) > 0 {
+				wantUpdated = updated
+			}
+			if !got.UpdatedAt.Equal(wantUpdated) {
+				t.Errorf("UpdatedAt = %v, want %v", got.UpdatedAt, wantUpdated)
+			}
+			if got.Status.IsFinished() != (got.CompletedAt != nil) {
+				t.Errorf("CompletedAt = %v for status %q", got.CompletedAt, got.Status.StringStatus())
+			}
+		})
+	}
+}
+
+func TestReplayEventsListing(t *testing.T) {
+	at := func(minutes int) time.Time {
+		return time.Date(2026, time.October, 1, 9, minutes, 0, 0, time.UTC)
+	}
+	event := func(kind TaskEventKind, taskID, minutes int, changes ...FieldChange) *TaskEvent {
+		return &TaskEvent{TaskID: taskID, OwnerID: 1, Kind: kind, Changes: changes, CreatedAt: at(minutes)}
+	}
+	title := func(value string) FieldChange {
+		return FieldChange{Field: FieldTitle, New: value}
+	}
+
+	tests := []struct {
+		name   string
+		events []*TaskEvent
+		want   []int
+	}{
+		{"newest first", []*TaskEvent{event(TaskCreated, 1, 0, title("a")), event(TaskCreated, 2, 1, title("b"))}, []int{2, 1}},
+		{"same time by id", []*TaskEvent{event(TaskCreated, 1, 0, title("a")), event(TaskCreated, 2, 0, title("b"))}, []int{2, 1}},
+		{"deleted", []*TaskEvent{event(TaskCreated, 1, 0, title("a")), event(TaskDeleted, 1, 1)}, []int{}},
+		{"restored", []*TaskEvent{event(TaskCreated, 1, 0, title("a")), event(TaskDeleted, 1, 1), event(TaskRestored, 1, 2)}, []int{1}},
+		{"archived", []*TaskEvent{event(TaskCreated, 1, 0, title("a")), event(TaskArchived, 1, 1)}, []int{}},
+		{"without create", []*TaskEvent{event(TaskUpdated, 1, 0, title("a")), event(TaskCreated, 2, 1, title("b"))}, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := ReplayEvents(tt.events)
+			if err != nil {
+				t.Fatalf("ReplayEvents error: %v", err)
+			}
+			ids := make([]int, 0, len(tasks))
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("ReplayEvents listed %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayEventsInvalid(t *testing.T) {
+	tests := []FieldChange{
+		{Field: FieldStatus, New: "bug"},
+		{Field: FieldPriority, New: "bug"},
+		{Field: FieldDue, New: "tomorrow"},
+		{Field: FieldProject, New: "INFRA-x"},
+		{Field: FieldParent, New: "x"},
+	}
+
+	for _, change := range tests {
+		t.Run(change.Field, func(t *testing.T) {
+			events := []*TaskEvent{{TaskID: 1, Kind: TaskCreated, Changes: []FieldChange{change}}}
+			_, err := ReplayEvents(events)
+			if err == nil {
+				t.Fatalf("ReplayEvents(%q = %q) succeeded, want error", change.Field, change.New)
+			}
+			if change.Field != FieldDue && change.Field != FieldParent && !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("ReplayEvents(%q = %q) error = %v, want ErrInvalidInput", change.Field, change.New, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// TaskFilter narrows a task listing. Zero values mean "no restriction".
+type TaskFilter struct {
+	Status    *TaskStatus
+	Overdue   bool
+	DueBefore *time.Time
+	DueAfter  *time.Time
+	// Tags keeps tasks carrying any of the tags, or all of them when
+	// AllTags is set.
+	Tags    []string
+	AllTags bool
+	// Project keeps the tasks of the project with this key. Without it
+	// tasks of archived projects are hidden.
+	Project string
+	// Sort orders the result, empty means newest first.
+	Sort []SortKey
+}
+
+// Normalize checks the filter and brings its tags and project key to the
+// form they are stored in.
+func (f TaskFilter) Normalize() (TaskFilter, error) {
+	if f.DueBefore != nil && f.DueAfter != nil && !f.DueAfter.Before(*f.DueBefore) {
+		return f, fmt.Errorf("%w: due-after must be earlier than due-before", ErrInvalidInput)
+	}
+	tags, err := NormalizeTags(f.Tags)
+	if err != nil {
+		return f, err
+	}
+	f.Tags = tags
+	if f.Project != "" {
+		if f.Project, err = NormalizeProjectKey(f.Project); err != nil {
+			return f, err
+		}
+	}
+	return f, nil
+}
+
+// Match applies the filter in Go, for storages that cannot do it in a query.
+func (f TaskFilter) Match(task *Task, now time.Time) bool {
+	if f.Status != nil && task.Status != *f.Status {
+		return false
+	}
+	if f.Overdue && !task.IsOverdue(now) {
+		return false
+	}
+	if f.DueBefore != nil && (task.DueAt == nil || !task.DueAt.Before(*f.DueBefore)) {
+		return false
+	}
+	if f.DueAfter != nil && (task.DueAt == nil || !task.DueAt.After(*f.DueAfter)) {
+		return false
+	}
+	if f.Project != "" && task.ProjectKey != f.Project {
+		return false
+	}
+	if len(f.Tags) > 0 && !f.matchTags(task) {
+		return false
+	}
+	return true
+}
+
+func (f TaskFilter) matchTags(task *Task) bool {
+	for _, tag := range f.Tags {
+		has := task.HasTag(tag)
+		if has && !f.AllTags {
+			return true
+		}
+		if !has && f.AllTags {
+			return false
+		}
+	}
+	return f.AllTags
+}
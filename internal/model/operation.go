@@ -0,0 +1,112 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// OperationKind is the task mutation an operation recorded.
+type OperationKind string
+
+const (
+	OperationCreate OperationKind = "create"
+	OperationUpdate OperationKind = "update"
+	OperationDelete OperationKind = "delete"
+)
+
+// Operation is a journal entry of a change a user made through the task
+// service. Before is the task as it was, nil for a create; After is the
+// task as the change left it, nil for a delete.
+type Operation struct {
+	ID     int
+	UserID int
+	Kind   OperationKind
+	TaskID int
+	Before *Task
+	After  *Task
+	// Policy is the subtask policy of a delete and Subtasks are the
+	// subtasks it changed along with the task.
+	Policy   ChildPolicy
+	Subtasks []SubtaskChange
+	// UndoneAt is set while the operation is undone and can be redone.
+	UndoneAt  *time.Time
+	CreatedAt time.Time
+}
+
+// SubtaskChange holds the images of a subtask a delete changed. After is
+// nil for a subtask a cascade took to the trash and has the new parent for
+// one a reparent moved.
+type SubtaskChange struct {
+	Before *Task
+	After  *Task
+}
+
+// SubtasksBefore returns the images the subtasks had before the operation.
+func (op *Operation) SubtasksBefore() []*Task {
+	tasks := make([]*Task, 0, len(op.Subtasks))
+	for _, sub := range op.Subtasks {
+		tasks = append(tasks, sub.Before)
+	}
+	return tasks
+}
+
+// Task returns the image that describes the task of the operation, the
+// latest one it has.
+func (op *Operation) Task() *Task {
+	if op.After != nil {
+		return op.After
+	}
+	return op.Before
+}
+
+// SameState reports whether two images of a task have the same fields a
+// user can change. Undo and redo use it to notice that a task was changed
+// after the operation.
+func SameState(a, b *Task) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Title == b.Title &&
+		a.Description == b.Description &&
+		a.Status == b.Status &&
+		a.Priority == b.Priority &&
+		sameTime(a.DueAt, b.DueAt) &&
+		slices.Equal(a.Tags, b.Tags) &&
+		sameInt(a.ProjectID, b.ProjectID) &&
+		sameInt(a.ParentID, b.ParentID)
+}
+
+// SameTasks reports whether two lists hold images of the same tasks, in
+// any order, with the same state.
+func SameTasks(a, b []*Task) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	byID := make(map[int]*Task, len(b))
+	for _, task := range b {
+		byID[task.ID] = task
+	}
+	for _, task := range a {
+		other, ok := byID[task.ID]
+		if !ok || !SameState(task, other) {
+			return false
+		}
+		delete(byID, task.ID)
+	}
+	return true
+}
+
+// sameTime compares to the microsecond, the precision the databases keep.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Truncate(time.Microsecond).Equal(b.Truncate(time.Microsecond))
+}
+
+func sameInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
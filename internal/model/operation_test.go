@@ -0,0 +1,48 @@
+package model
+
+import "testing"
+
+func TestSameTasks(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name string
+		a, b []*Task
+		want bool
+	}{
+		{name: "both empty", want: true},
+		{
+			name: "same tasks in another order",
+			a:    []*Task{{ID: 2, Title: "a", ParentID: &parentID}, {ID: 3, Title: "b"}},
+			b:    []*Task{{ID: 3, Title: "b"}, {ID: 2, Title: "a", ParentID: &parentID}},
+			want: true,
+		},
+		{
+			name: "task added",
+			a:    []*Task{{ID: 2, Title: "a"}, {ID: 4, Title: "c"}},
+			b:    []*Task{{ID: 2, Title: "a"}},
+		},
+		{
+			name: "task replaced",
+			a:    []*Task{{ID: 2, Title: "a"}, {ID: 4, Title: "b"}},
+			b:    []*Task{{ID: 2, Title: "a"}, {ID: 3, Title: "b"}},
+		},
+		{
+			name: "task changed",
+			a:    []*Task{{ID: 2, Title: "a", ParentID: &parentID}},
+			b:    []*Task{{ID: 2, Title: "a"}},
+		},
+		{
+			name: "same task twice",
+			a:    []*Task{{ID: 2, Title: "a"}, {ID: 2, Title: "a"}},
+			b:    []*Task{{ID: 2, Title: "a"}, {ID: 3, Title: "b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameTasks(tt.a, tt.b); got != tt.want {
+				t.Errorf("SameTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskPriority values grow with importance, so "ORDER BY priority DESC"
+// puts the most important tasks first. Zero means "not set" and is replaced
+// with PriorityMedium on create.
+type TaskPriority int
+
+const (
+	PriorityLow TaskPriority = iota + 1
+	PriorityMedium
+	PriorityHigh
+	PriorityCritical
+)
+
+func (p TaskPriority) StringPriority() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityMedium:
+		return "medium"
+	case PriorityHigh:
+		return "high"
+	case PriorityCritical:
+		return "critical"
+	default:
+		return "bug"
+	}
+}
+
+func (p TaskPriority) Valid() bool {
+	return p >= PriorityLow && p <= PriorityCritical
+}
+
+var priorityAliases = []struct {
+	priority TaskPriority
+	names    []string
+}{
+	{PriorityLow, []string{"low", "l", "1"}},
+	{PriorityMedium, []string{"medium", "m", "2", "normal"}},
+	{PriorityHigh, []string{"high", "h", "3"}},
+	{PriorityCritical, []string{"critical", "c", "4", "urgent"}},
+}
+
+// ParseTaskPriority accepts names, one letter abbreviations and numeric
+// values in any case.
+func ParseTaskPriority(input string) (TaskPriority, error) {
+	normalized := strings.ToLower(strings.TrimSpace(input))
+
+	for _, alias := range priorityAliases {
+		for _, name := range alias.names {
+			if name == normalized {
+				return alias.priority, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("%w: unknown task priority %q, accepted: %s", ErrInvalidInput, input, AcceptedPriorities())
+}
+
+// AcceptedPriorities describes the values ParseTaskPriority understands.
+func AcceptedPriorities() string {
+	parts := make([]string, 0, len(priorityAliases))
+	for _, alias := range priorityAliases {
+		parts = append(parts, fmt.Sprintf("%s (%s)", alias.names[0], strings.Join(alias.names[1:], ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
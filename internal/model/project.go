@@ -0,0 +1,85 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	minProjectKeyLen = 2
+	maxProjectKeyLen = 10
+)
+
+// Project groups tasks. Tasks of a project are numbered per project and
+// shown with the project key, e.g. INFRA-12.
+type Project struct {
+	ID          int
+	OwnerID     int
+	Key         string
+	Name        string
+	Description string
+	ArchivedAt  *time.Time
+	CreatedAt   time.Time
+	// Tasks is the number of tasks in the project.
+	Tasks int
+}
+
+func (p *Project) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// NormalizeProjectKey uppercases a key and checks that it is 2 to 10
+// letters or digits starting with a letter.
+func NormalizeProjectKey(key string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(key))
+	if len(normalized) < minProjectKeyLen || len(normalized) > maxProjectKeyLen {
+		return "", fmt.Errorf("%w: project key %q must be %d to %d characters", ErrInvalidInput, key, minProjectKeyLen, maxProjectKeyLen)
+	}
+	for i, r := range normalized {
+		isLetter := r >= 'A' && r <= 'Z'
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && (i == 0 || !isDigit) {
+			return "", fmt.Errorf("%w: project key %q must be latin letters and digits starting with a letter", ErrInvalidInput, key)
+		}
+	}
+	return normalized, nil
+}
+
+// DisplayID is the project scoped id (INFRA-12) for tasks in a project and
+// the plain id otherwise.
+func (t *Task) DisplayID() string {
+	if t.ProjectKey == "" {
+		return strconv.Itoa(t.ID)
+	}
+	return fmt.Sprintf("%s-%d", t.ProjectKey, t.Number)
+}
+
+// TaskRef is what a user types to point at a task: a plain id ("42") or a
+// project scoped one ("INFRA-12").
+type TaskRef struct {
+	ID         int
+	ProjectKey string
+	Number     int
+}
+
+func ParseTaskRef(input string) (TaskRef, error) {
+	input = strings.TrimSpace(input)
+	if id, err := strconv.Atoi(input); err == nil {
+		if id <= 0 {
+			return TaskRef{}, fmt.Errorf("%w: invalid task id %d", ErrInvalidInput, id)
+		}
+		return TaskRef{ID: id}, nil
+	}
+
+	key, numberStr, ok := strings.Cut(input, "-")
+	if ok {
+		number, err := strconv.Atoi(numberStr)
+		if normalized, keyErr := NormalizeProjectKey(key); err == nil && keyErr == nil && number > 0 {
+			return TaskRef{ProjectKey: normalized, Number: number}, nil
+		}
+	}
+
+	return TaskRef{}, fmt.Errorf("%w: invalid task id %q, use a number or KEY-NUMBER", ErrInvalidInput, input)
+}
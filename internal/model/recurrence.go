@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// Recurrence is a task template with a schedule. The worker creates a task
+// from it for every occurrence of its rule, see Task.RecurrenceID.
+type Recurrence struct {
+	ID      int
+	OwnerID int
+	// Rule is a cron-style schedule such as "0 9 * * MON" or "@daily".
+	Rule string
+	// Timezone is the IANA name the rule is evaluated in.
+	Timezone    string
+	Title       string
+	Description string
+	Priority    TaskPriority
+	Tags        []string
+	// ProjectID is nil for templates outside projects. ProjectKey is filled
+	// by the repository.
+	ProjectID  *int
+	ProjectKey string
+	// DueIn, when positive, gives every occurrence a due date that long
+	// after its scheduled time.
+	DueIn time.Duration
+	// NextAt is the scheduled time of the next occurrence to create.
+	NextAt    time.Time
+	CreatedAt time.Time
+}
+
+// Occurrence is the task created for the occurrence of r scheduled at at.
+func (r *Recurrence) Occurrence(at time.Time) *Task {
+	id := r.ID
+	task := &Task{
+		OwnerID:      r.OwnerID,
+		Title:        r.Title,
+		Description:  r.Description,
+		Priority:     r.Priority,
+		Tags:         append([]string(nil), r.Tags...),
+		ProjectID:    r.ProjectID,
+		RecurrenceID: &id,
+		OccurrenceAt: &at,
+	}
+	if r.DueIn > 0 {
+		due := at.Add(r.DueIn)
+		task.DueAt = &due
+	}
+	return task
+}
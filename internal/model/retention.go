@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// KeepForever as a retention keeps done tasks until they are deleted by
+// hand.
+const KeepForever time.Duration = -1
+
+// RetentionPolicy decides how long the cleaner keeps a done task after it
+// was completed.
+type RetentionPolicy struct {
+	Default time.Duration
+	// Project and Tag override Default for the tasks of a project, by key,
+	// and for tasks carrying a tag.
+	Project map[string]time.Duration
+	Tag     map[string]time.Duration
+}
+
+// Retention returns how long task is kept. A tag override wins over a
+// project one, and of several tag overrides the longest wins, so a task is
+// never purged earlier than any of its rules allows.
+func (p RetentionPolicy) Retention(task *Task) time.Duration {
+	var retention time.Duration
+	tagged := false
+	for _, tag := range task.Tags {
+		if d, ok := p.Tag[tag]; ok {
+			if !tagged || longerRetention(d, retention) {
+				retention = d
+			}
+			tagged = true
+		}
+	}
+	if tagged {
+		return retention
+	}
+
+	if d, ok := p.Project[task.ProjectKey]; ok && task.ProjectKey != "" {
+		return d
+	}
+	return p.Default
+}
+
+// Expired reports whether task is done and was completed longer than its
+// retention before now.
+func (p RetentionPolicy) Expired(task *Task, now time.Time) bool {
+	if task.Status != Closed || task.CompletedAt == nil {
+		return false
+	}
+	retention := p.Retention(task)
+	return retention != KeepForever && now.Sub(*task.CompletedAt) >= retention
+}
+
+func longerRetention(a, b time.Duration) bool {
+	if a == KeepForever || b == KeepForever {
+		return a == KeepForever && b != KeepForever
+	}
+	return a > b
+}
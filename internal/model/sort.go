@@ -0,0 +1,110 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type SortField int
+
+const (
+	SortByPriority SortField = iota
+	SortByDue
+	SortByCreated
+)
+
+// SortKey is one key of a listing order. Reverse flips the field's natural
+// direction: priority from most important, due from the earliest date and
+// created from the newest task.
+type SortKey struct {
+	Field   SortField
+	Reverse bool
+}
+
+var sortFieldNames = map[string]SortField{
+	"priority":   SortByPriority,
+	"due":        SortByDue,
+	"due_at":     SortByDue,
+	"created":    SortByCreated,
+	"created_at": SortByCreated,
+}
+
+// ParseSort reads a comma separated key list such as "priority,due,created".
+// A leading "-" reverses a key: "-created" lists the oldest tasks first.
+func ParseSort(input string) ([]SortKey, error) {
+	var keys []SortKey
+	seen := make(map[SortField]bool)
+
+	for _, part := range strings.Split(input, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+
+		key := SortKey{}
+		if strings.HasPrefix(name, "-") {
+			key.Reverse = true
+			name = name[1:]
+		}
+
+		field, ok := sortFieldNames[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown sort key %q, accepted: priority, due, created", ErrInvalidInput, part)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("%w: sort key %q given twice", ErrInvalidInput, name)
+		}
+		seen[field] = true
+
+		key.Field = field
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// SortTasks applies in Go the same order repository.OrderBy gives the SQL
+// backends. tasks must already be sorted newest first, the stable sort
+// keeps that as the tie breaker.
+func SortTasks(tasks []*Task, keys []SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		for _, key := range keys {
+			if c := compareTasks(tasks[i], tasks[j], key); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// compareTasks returns a negative number when a goes before b.
+func compareTasks(a, b *Task, key SortKey) int {
+	var c int
+	switch key.Field {
+	case SortByPriority:
+		c = int(b.Priority) - int(a.Priority)
+	case SortByDue:
+		// No due date sorts last in either direction.
+		switch {
+		case a.DueAt == nil && b.DueAt == nil:
+			return 0
+		case a.DueAt == nil:
+			return 1
+		case b.DueAt == nil:
+			return -1
+		}
+		c = a.DueAt.Compare(*b.DueAt)
+	case SortByCreated:
+		c = b.CreatedAt.Compare(a.CreatedAt)
+	}
+
+	if key.Reverse {
+		return -c
+	}
+	return c
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChildPolicy says what deleting a task does to its subtasks.
+type ChildPolicy int
+
+const (
+	// ChildrenRefuse keeps the task when it has subtasks.
+	ChildrenRefuse ChildPolicy = iota
+	// ChildrenCascade deletes the whole subtree.
+	ChildrenCascade
+	// ChildrenReparent moves the subtasks to the parent of the deleted
+	// task, or to the top level.
+	ChildrenReparent
+)
+
+var childPolicyNames = map[ChildPolicy]string{
+	ChildrenRefuse:   "refuse",
+	ChildrenCascade:  "cascade",
+	ChildrenReparent: "reparent",
+}
+
+func (p ChildPolicy) String() string {
+	if name, ok := childPolicyNames[p]; ok {
+		return name
+	}
+	return "bug"
+}
+
+func ParseChildPolicy(input string) (ChildPolicy, error) {
+	normalized := strings.ToLower(strings.TrimSpace(input))
+	for policy, name := range childPolicyNames {
+		if name == normalized {
+			return policy, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown subtask policy %q, accepted: refuse, cascade, reparent", ErrInvalidInput, input)
+}
+
+// UpdateOptions tune TaskService.UpdateTask.
+type UpdateOptions struct {
+	// Force finishes a task even if some of its subtasks are still open.
+	Force bool
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const maxTagLen = 32
+
+// Tag is a label owned by a user. Tasks reference tags through the
+// task_tags join table, so renaming a tag changes it on every task.
+type Tag struct {
+	ID      int
+	OwnerID int
+	Name    string
+	// Tasks is the number of tasks carrying the tag.
+	Tasks int
+}
+
+// NormalizeTag lowercases a tag name and drops a leading "#". Names are
+// limited to letters, digits and "-_./:" so they can be listed without
+// quoting.
+func NormalizeTag(name string) (string, error) {
+	normalized := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+	if normalized == "" {
+		return "", fmt.Errorf("%w: tag name is required", ErrInvalidInput)
+	}
+	if len([]rune(normalized)) > maxTagLen {
+		return "", fmt.Errorf("%w: tag %q is longer than %d characters", ErrInvalidInput, name, maxTagLen)
+	}
+	for _, r := range normalized {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_./:", r) {
+			return "", fmt.Errorf("%w: tag %q may only contain letters, digits and -_./:", ErrInvalidInput, name)
+		}
+	}
+	return normalized, nil
+}
+
+// NormalizeTags normalizes every name and returns them sorted without
+// duplicates.
+func NormalizeTags(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	tags := make([]string, 0, len(names))
+
+	for _, name := range names {
+		tag, err := NormalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// Retag replaces sources with target in a sorted list of tags, an empty
+// target only removes them. It reports whether the list changed.
+func Retag(tags, sources []string, target string) ([]string, bool) {
+	retagged := make([]string, 0, len(tags))
+	changed := false
+	for _, name := range tags {
+		if slices.Contains(sources, name) {
+			changed = true
+			continue
+		}
+		retagged = append(retagged, name)
+	}
+	if !changed {
+		return tags, false
+	}
+
+	if target != "" && !slices.Contains(retagged, target) {
+		retagged = append(retagged, target)
+		sort.Strings(retagged)
+	}
+	return retagged, true
+}
+
+// HasTag reports whether the task carries tag.
+func (t *Task) HasTag(tag string) bool {
+	for _, name := range t.Tags {
+		if name == tag {
+			return true
+		}
+	}
+	return false
+}
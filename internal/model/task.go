@@ -1,22 +1,63 @@
 package model
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
 const (
 	Open TaskStatus = iota
 	Closed
+	InProgress
+	Blocked
+	Cancelled
 )
 
 type TaskStatus int
 
 type Task struct {
 	ID          int
+	OwnerID     int
 	Title       string
 	Description string
 	Status      TaskStatus
-	CreatedAt   time.Time
+	Priority    TaskPriority
+	DueAt       *time.Time
+	// Tags are normalized names sorted alphabetically.
+	Tags []string
+	// ProjectID is nil for tasks outside projects. ProjectKey and Number
+	// are filled by the repository and make up the display id.
+	ProjectID  *int
+	ProjectKey string
+	Number     int
+	// ParentID is nil for top level tasks.
+	ParentID *int
+	// OpenDependencies is the number of unfinished tasks this one depends
+	// on, filled by the repository.
+	OpenDependencies int
+	// RecurrenceID links a task created by a recurrence to it, OccurrenceAt
+	// is the scheduled time it was created for. Both are nil otherwise.
+	RecurrenceID *int
+	OccurrenceAt *time.Time
+	CreatedAt    time.Time
+	// UpdatedAt is the time of the last change. CompletedAt is when the task
+	// was last finished and nil while it is not. Both are kept by the
+	// repository.
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+	// DeletedAt is set while the task is in the trash.
+	DeletedAt *time.Time
+}
+
+// IsFinished reports whether no more work is expected on a task in this status.
+func (s TaskStatus) IsFinished() bool {
+	return s == Closed || s == Cancelled
+}
+
+// IsOverdue reports whether the task is still unfinished after its due date.
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.DueAt != nil && t.DueAt.Before(now) && !t.Status.IsFinished()
 }
 
 func (s TaskStatus) StringStatus() string {
@@ -25,18 +66,52 @@ func (s TaskStatus) StringStatus() string {
 		return "not done"
 	case Closed:
 		return "done"
+	case InProgress:
+		return "in progress"
+	case Blocked:
+		return "blocked"
+	case Cancelled:
+		return "cancelled"
 	default:
 		return "bug"
 	}
 }
 
-func ParseTaskStatus(input string) TaskStatus {
-	switch input {
-	case "0", "not done":
-		return Open
-	case "1", "done":
-		return Closed
-	default:
-		return 0
+// statusAliases maps every accepted spelling to a status. The first name
+// of each entry is the canonical one.
+var statusAliases = []struct {
+	status TaskStatus
+	names  []string
+}{
+	{Open, []string{"not_done", "0", "open", "pending", "todo"}},
+	{InProgress, []string{"in_progress", "2", "started"}},
+	{Blocked, []string{"blocked", "3"}},
+	{Closed, []string{"done", "1", "closed", "completed"}},
+	{Cancelled, []string{"cancelled", "4", "canceled"}},
+}
+
+// ParseTaskStatus accepts canonical names, numeric values and aliases in any
+// case; spaces and dashes are treated as underscores ("not done").
+func ParseTaskStatus(input string) (TaskStatus, error) {
+	normalized := strings.ToLower(strings.TrimSpace(input))
+	normalized = strings.NewReplacer(" ", "_", "-", "_").Replace(normalized)
+
+	for _, alias := range statusAliases {
+		for _, name := range alias.names {
+			if name == normalized {
+				return alias.status, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("%w: unknown task status %q, accepted: %s", ErrInvalidInput, input, AcceptedStatuses())
+}
+
+// AcceptedStatuses describes the values ParseTaskStatus understands.
+func AcceptedStatuses() string {
+	parts := make([]string, 0, len(statusAliases))
+	for _, alias := range statusAliases {
+		parts = append(parts, fmt.Sprintf("%s (%s)", alias.names[0], strings.Join(alias.names[1:], ", ")))
 	}
+	return strings.Join(parts, "; ")
 }
@@ -0,0 +1,144 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// UrgencyWeights are the coefficients of the urgency score. Every part of
+// the score is a coefficient times a factor between 0 and 1.
+type UrgencyWeights struct {
+	Priority float64
+	Due      float64
+	Age      float64
+	// AgeMax is the age at which the age factor reaches 1.
+	AgeMax  time.Duration
+	Tags    float64
+	Blocked float64
+	// Tag adds a coefficient for tasks carrying a specific tag, negative
+	// values push tasks down.
+	Tag map[string]float64
+}
+
+// UrgencyPart is one term of an urgency score.
+type UrgencyPart struct {
+	Name        string
+	Coefficient float64
+	Factor      float64
+	// Reason explains the factor, e.g. "due in 2d".
+	Reason string
+}
+
+func (p UrgencyPart) Value() float64 {
+	return p.Coefficient * p.Factor
+}
+
+type Urgency struct {
+	Score float64
+	Parts []UrgencyPart
+}
+
+// RankedTask is a task with its urgency.
+type RankedTask struct {
+	Task    *Task
+	Urgency Urgency
+}
+
+// Urgency scores a task, parts that contribute nothing are left out.
+func (w UrgencyWeights) Urgency(task *Task, now time.Time) Urgency {
+	var urgency Urgency
+	add := func(name string, coefficient, factor float64, reason string) {
+		if coefficient == 0 || factor == 0 {
+			return
+		}
+		part := UrgencyPart{Name: name, Coefficient: coefficient, Factor: factor, Reason: reason}
+		urgency.Parts = append(urgency.Parts, part)
+		urgency.Score += part.Value()
+	}
+
+	if task.Priority.Valid() {
+		add("priority", w.Priority, float64(task.Priority)/float64(PriorityCritical), task.Priority.StringPriority())
+	}
+	if task.DueAt != nil {
+		add("due", w.Due, dueFactor(task.DueAt.Sub(now)), formatDueIn(task.DueAt.Sub(now)))
+	}
+	if w.AgeMax > 0 {
+		age := now.Sub(task.CreatedAt)
+		add("age", w.Age, min(max(float64(age)/float64(w.AgeMax), 0), 1), fmt.Sprintf("%s old", formatDays(age)))
+	}
+	add("tags", w.Tags, tagsFactor(len(task.Tags)), fmt.Sprintf("%d tag(s)", len(task.Tags)))
+	for _, tag := range task.Tags {
+		add("tag:"+tag, w.Tag[tag], 1, "")
+	}
+	if task.IsWaiting() {
+		add("blocked", w.Blocked, 1, fmt.Sprintf("waits for %d task(s)", task.OpenDependencies))
+	} else if task.Status == Blocked {
+		add("blocked", w.Blocked, 1, "status blocked")
+	}
+
+	return urgency
+}
+
+// Rank scores the unfinished tasks and returns them most urgent first.
+func (w UrgencyWeights) Rank(tasks []*Task, now time.Time) []RankedTask {
+	ranked := make([]RankedTask, 0, len(tasks))
+	for _, task := range tasks {
+		if task.Status.IsFinished() {
+			continue
+		}
+		ranked = append(ranked, RankedTask{Task: task, Urgency: w.Urgency(task, now)})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Urgency.Score != ranked[j].Urgency.Score {
+			return ranked[i].Urgency.Score > ranked[j].Urgency.Score
+		}
+		return ranked[i].Task.ID < ranked[j].Task.ID
+	})
+	return ranked
+}
+
+// dueFactor grows linearly from 0.2 for tasks due in two weeks or later to
+// 1 for tasks a week overdue.
+func dueFactor(left time.Duration) float64 {
+	days := -left.Hours() / 24
+	switch {
+	case days >= 7:
+		return 1
+	case days >= -14:
+		return (days+14)*0.8/21 + 0.2
+	default:
+		return 0.2
+	}
+}
+
+// tagsFactor rewards having tags at all more than having many.
+func tagsFactor(count int) float64 {
+	switch count {
+	case 0:
+		return 0
+	case 1:
+		return 0.8
+	case 2:
+		return 0.9
+	default:
+		return 1
+	}
+}
+
+func formatDueIn(left time.Duration) string {
+	if left < 0 {
+		return fmt.Sprintf("%s overdue", formatDays(-left))
+	}
+	return fmt.Sprintf("due in %s", formatDays(left))
+}
+
+func formatDays(d time.Duration) string {
+	if d < day {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d/day))
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+type User struct {
+	ID           int
+	Login        string
+	PasswordHash string
+	// Timezone is an IANA zone name used to read and show times for the
+	// user. Empty means the application default.
+	Timezone  string
+	CreatedAt time.Time
+}
+
+type Session struct {
+	TokenHash string
+	UserID    int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func (s *Session) Expired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,142 @@
+package dependency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+const uniqueViolationCode = "23505"
+
+var _ rep.DependencyRepository = (*repository)(nil)
+
+type repository struct {
+	pool *pgxpool.Pool
+	log  zerolog.Logger
+}
+
+func NewRepository(pool *pgxpool.Pool) *repository {
+	return &repository{
+		pool: pool,
+		log:  logger.GetLogger("repository.dependency"),
+	}
+}
+
+// Add only links tasks of the same owner, visible to the caller. The
+// owner's user row is locked while the graph is checked, so concurrent adds
+// cannot close a cycle together.
+func (r *repository) Add(ctx context.Context, taskID, dependsOnID int) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var taskOwnerID int
+	query := `SELECT u.id FROM tasks t JOIN tasks d ON d.owner_id = t.owner_id JOIN users u ON u.id = t.owner_id
+		WHERE t.id = $1 AND d.id = $2 AND ($3::int IS NULL OR t.owner_id = $3) FOR NO KEY UPDATE OF u`
+	if err := tx.QueryRow(ctx, query, taskID, dependsOnID, ownerID).Scan(&taskOwnerID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("task with id %d or %d %w", taskID, dependsOnID, model.ErrNotFound)
+		}
+		return fmt.Errorf("failed to lock owner: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, graphQuery, taskOwnerID)
+	if err != nil {
+		return fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	graph, err := scanGraph(rows)
+	if err != nil {
+		return err
+	}
+	if err := graph.CheckDependency(taskID, dependsOnID); err != nil {
+		return err
+	}
+
+	query = "INSERT INTO task_dependencies (task_id, depends_on_id) VALUES ($1, $2)"
+	if _, err := tx.Exec(ctx, query, taskID, dependsOnID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return fmt.Errorf("dependency of task %d on %d %w", taskID, dependsOnID, model.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("task_id", taskID).
+		Int("depends_on_id", dependsOnID).
+		Msg("Dependency added")
+	return nil
+}
+
+func (r *repository) Remove(ctx context.Context, taskID, dependsOnID int) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM task_dependencies WHERE task_id = $1 AND depends_on_id = $2
+		AND task_id IN (SELECT id FROM tasks WHERE $3::int IS NULL OR owner_id = $3)`
+	result, err := r.pool.Exec(ctx, query, taskID, dependsOnID, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("dependency of task %d on %d %w", taskID, dependsOnID, model.ErrNotFound)
+	}
+
+	r.log.Info().
+		Int("task_id", taskID).
+		Int("depends_on_id", dependsOnID).
+		Msg("Dependency removed")
+	return nil
+}
+
+const graphQuery = `SELECT d.task_id, d.depends_on_id FROM task_dependencies d JOIN tasks t ON t.id = d.task_id
+	WHERE $1::int IS NULL OR t.owner_id = $1`
+
+func (r *repository) Graph(ctx context.Context) (model.DependencyGraph, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, graphQuery, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	return scanGraph(rows)
+}
+
+func scanGraph(rows pgx.Rows) (model.DependencyGraph, error) {
+	defer rows.Close()
+
+	graph := make(model.DependencyGraph)
+	for rows.Next() {
+		var taskID, dependsOnID int
+		if err := rows.Scan(&taskID, &dependsOnID); err != nil {
+			return nil, fmt.Errorf("failed scan dependency: %w", err)
+		}
+		graph[taskID] = append(graph[taskID], dependsOnID)
+	}
+	return graph, rows.Err()
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"techno/internal/model"
+)
+
+// EncodeChanges turns the field changes of a task event into JSON, no
+// changes give an empty list.
+func EncodeChanges(changes []model.FieldChange) (string, error) {
+	if changes == nil {
+		changes = []model.FieldChange{}
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode task event changes: %w", err)
+	}
+	return string(data), nil
+}
+
+// DecodeChanges reverses EncodeChanges.
+func DecodeChanges(data []byte) ([]model.FieldChange, error) {
+	var changes []model.FieldChange
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode task event changes: %w", err)
+	}
+	return changes, nil
+}
+
+// RestoreEvents builds the events of a trash restore from the image of the
+// task while it was deleted and the restored tasks. Only the task itself
+// can change on the way, it loses a parent that is still deleted.
+func RestoreEvents(actorID *int, deleted *model.Task, restored []*model.Task) []*model.TaskEvent {
+	events := make([]*model.TaskEvent, 0, len(restored))
+	for _, task := range restored {
+		before := task
+		if task.ID == deleted.ID {
+			before = deleted
+		}
+		events = append(events, model.NewTaskEvent(model.TaskRestored, actorID, before, task))
+	}
+	return events
+}
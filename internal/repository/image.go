@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"techno/internal/model"
+)
+
+// EncodeTaskImage turns a task image of the journal into JSON, a nil task
+// gives nil so it is stored as NULL.
+func EncodeTaskImage(task *model.Task) ([]byte, error) {
+	if task == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task image: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeTaskImage reverses EncodeTaskImage.
+func DecodeTaskImage(data []byte) (*model.Task, error) {
+	if data == nil {
+		return nil, nil
+	}
+	task := &model.Task{}
+	if err := json.Unmarshal(data, task); err != nil {
+		return nil, fmt.Errorf("failed to decode task image: %w", err)
+	}
+	return task, nil
+}
+
+// EncodeSubtaskImages turns the subtask images of an operation into JSON,
+// no subtasks give an empty list.
+func EncodeSubtaskImages(subtasks []model.SubtaskChange) (string, error) {
+	if subtasks == nil {
+		subtasks = []model.SubtaskChange{}
+	}
+	data, err := json.Marshal(subtasks)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode subtask images: %w", err)
+	}
+	return string(data), nil
+}
+
+// DecodeSubtaskImages reverses EncodeSubtaskImages.
+func DecodeSubtaskImages(data []byte) ([]model.SubtaskChange, error) {
+	var subtasks []model.SubtaskChange
+	if err := json.Unmarshal(data, &subtasks); err != nil {
+		return nil, fmt.Errorf("failed to decode subtask images: %w", err)
+	}
+	return subtasks, nil
+}
@@ -3,13 +3,133 @@ package repository
 import (
 	"context"
 	"techno/internal/model"
+	"time"
 )
 
+// TaskRepository journals the changes a user makes in the transaction that
+// makes them, see Journaled.
 type TaskRepository interface {
 	CreateTask(ctx context.Context, task *model.Task) error
 	GetByID(ctx context.Context, id int) (*model.Task, error)
 	GetAll(ctx context.Context) ([]*model.Task, error)
 	GetByStatus(ctx context.Context, status model.TaskStatus) ([]*model.Task, error)
-	UpdateTask(ctx context.Context, task *model.Task) error
-	DeleteTask(ctx context.Context, id int) error
+	List(ctx context.Context, filter model.TaskFilter) ([]*model.Task, error)
+	GetByProjectNumber(ctx context.Context, projectKey string, number int) (*model.Task, error)
+	// GetSubtasks returns every descendant of the task, oldest first.
+	GetSubtasks(ctx context.Context, id int) ([]*model.Task, error)
+	// UpdateTask writes the task if the stored one still has the state of
+	// expected, the task the caller checked the change against, and fails
+	// with model.ErrConflict otherwise. A nil expected skips the check.
+	UpdateTask(ctx context.Context, task *model.Task, expected *model.Task) error
+	// DeleteTask moves the task to the trash, the other methods do not see
+	// tasks in the trash. expected is checked the way UpdateTask does. With
+	// expected set, the subtasks the policy touches must also be exactly
+	// the ones of subtasks, in their state, so a redo changes what the
+	// delete changed and nothing else. The journal keeps the policy and the
+	// images of every subtask the delete changed.
+	DeleteTask(ctx context.Context, id int, policy model.ChildPolicy, expected *model.Task, subtasks []*model.Task) error
+	// UndeleteTask reverts the delete of op in one transaction: the task
+	// and the subtasks the delete took to the trash come back from it, and
+	// the subtasks it moved to the parent go back under the task. All of
+	// them must still be the way the delete left them, otherwise nothing
+	// changes and it fails with model.ErrConflict. It marks the operation
+	// an undo replays.
+	UndeleteTask(ctx context.Context, op *model.Operation) error
+}
+
+// TrashRepository works on the tasks DeleteTask moved to the trash.
+type TrashRepository interface {
+	// List returns deleted tasks, latest deleted first.
+	List(ctx context.Context) ([]*model.Task, error)
+	// Restore takes a deleted task out of the trash together with the
+	// subtasks deleted along with it and returns them, the task first. A
+	// parent that is still in the trash is dropped. A restore is not
+	// journaled, but it marks the operation an undo or a redo replays.
+	Restore(ctx context.Context, id int) ([]*model.Task, error)
+	// Purge deletes for good the tasks deleted before the given time and
+	// returns how many there were.
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+// ArchiveRepository moves finished tasks out of the task list and back.
+type ArchiveRepository interface {
+	// Archive moves a task with all its fields to the archive in one
+	// transaction. Its subtasks move to its parent.
+	Archive(ctx context.Context, id int) error
+	// List returns archived tasks, latest archived first. A non-empty query
+	// keeps the tasks whose title or description contains it.
+	List(ctx context.Context, query string) ([]*model.ArchivedTask, error)
+	// Restore moves an archived task back under its old id. The parent and
+	// the recurrence are dropped if they no longer exist.
+	Restore(ctx context.Context, id int) (*model.Task, error)
+}
+
+// JournalRepository keeps the task operations of the current user for
+// undo and redo. The task repositories write them.
+type JournalRepository interface {
+	// Done returns up to limit operations that are not undone, latest
+	// first.
+	Done(ctx context.Context, limit int) ([]*model.Operation, error)
+	// LastUndone returns the operation that was undone last.
+	LastUndone(ctx context.Context) (*model.Operation, error)
+}
+
+// EventRepository reads the audit trail the task, trash and archive
+// repositories write with every change.
+type EventRepository interface {
+	// History returns the events of a task, oldest first.
+	History(ctx context.Context, taskID int) ([]*model.TaskEvent, error)
+	// Until returns the events of the tasks of the current owner up to and
+	// including at, in the order they happened.
+	Until(ctx context.Context, at time.Time) ([]*model.TaskEvent, error)
+}
+
+type ProjectRepository interface {
+	CreateProject(ctx context.Context, project *model.Project) error
+	GetByKey(ctx context.Context, key string) (*model.Project, error)
+	List(ctx context.Context, includeArchived bool) ([]*model.Project, error)
+	SetArchived(ctx context.Context, key string, archived bool) error
+}
+
+type DependencyRepository interface {
+	// Add records that taskID cannot start until dependsOnID is finished.
+	// It fails with *model.CycleError when the edge would close a cycle.
+	Add(ctx context.Context, taskID, dependsOnID int) error
+	Remove(ctx context.Context, taskID, dependsOnID int) error
+	// Graph returns the dependencies between the tasks of the current owner.
+	Graph(ctx context.Context) (model.DependencyGraph, error)
+}
+
+type RecurrenceRepository interface {
+	CreateRecurrence(ctx context.Context, recurrence *model.Recurrence) error
+	List(ctx context.Context) ([]*model.Recurrence, error)
+	// DeleteRecurrence stops a recurrence, the tasks it created stay.
+	DeleteRecurrence(ctx context.Context, id int) error
+	// Due returns the recurrences whose next occurrence is scheduled at or
+	// before now or that have no unfinished task left. Recurrences of an
+	// archived project are paused and left out until it is restored.
+	Due(ctx context.Context, now time.Time) ([]*model.Recurrence, error)
+	// Advance moves NextAt of a recurrence from from to to. It reports false
+	// when NextAt is no longer from, i.e. another run got there first.
+	Advance(ctx context.Context, id int, from, to time.Time) (bool, error)
+}
+
+type TagRepository interface {
+	List(ctx context.Context) ([]*model.Tag, error)
+	Rename(ctx context.Context, from, to string) error
+	Merge(ctx context.Context, sources []string, target string) error
+	Delete(ctx context.Context, name string) error
+}
+
+type UserRepository interface {
+	CreateUser(ctx context.Context, user *model.User) error
+	GetByID(ctx context.Context, id int) (*model.User, error)
+	GetByLogin(ctx context.Context, login string) (*model.User, error)
+	UpdateTimezone(ctx context.Context, id int, timezone string) error
+}
+
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session *model.Session) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*model.Session, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
 }
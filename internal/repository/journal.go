@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/auth"
+	"techno/internal/model"
+)
+
+// Replay marks a change that undoes or redoes an operation of the journal.
+// The task repositories then mark that operation in the transaction of the
+// change instead of journaling the change as a new operation.
+type Replay struct {
+	OperationID int
+	// Undo is true for an undo and false for a redo.
+	Undo bool
+}
+
+type replayKey struct{}
+
+func WithReplay(ctx context.Context, replay Replay) context.Context {
+	return context.WithValue(ctx, replayKey{}, replay)
+}
+
+func ReplayFromContext(ctx context.Context) (Replay, bool) {
+	replay, ok := ctx.Value(replayKey{}).(Replay)
+	return replay, ok
+}
+
+// Journaled reports whether the task repositories journal the changes made
+// with ctx as new operations. Changes of the system principal, such as the
+// cleaner deleting old tasks, and undo and redo themselves are not
+// journaled.
+func Journaled(ctx context.Context) bool {
+	if p, _ := auth.FromContext(ctx); p.IsSystem() {
+		return false
+	}
+	_, replay := ReplayFromContext(ctx)
+	return !replay
+}
+
+// ReplayConflict is the error for an operation that another undo or redo
+// has marked in the meantime.
+func ReplayConflict(replay Replay) error {
+	if replay.Undo {
+		return fmt.Errorf("%w: operation %d is already undone", model.ErrConflict, replay.OperationID)
+	}
+	return fmt.Errorf("%w: operation %d is already redone", model.ErrConflict, replay.OperationID)
+}
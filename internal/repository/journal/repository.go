@@ -0,0 +1,90 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+const operationColumns = "id, user_id, kind, task_id, before_image, after_image, child_policy, subtask_images, undone_at, created_at"
+
+var _ rep.JournalRepository = (*repository)(nil)
+
+type repository struct {
+	pool *pgxpool.Pool
+	log  zerolog.Logger
+}
+
+func NewRepository(pool *pgxpool.Pool) *repository {
+	return &repository{
+		pool: pool,
+		log:  logger.GetLogger("repository.journal"),
+	}
+}
+
+func (r *repository) Done(ctx context.Context, limit int) ([]*model.Operation, error) {
+	userID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + operationColumns + " FROM operations WHERE user_id = $1 AND undone_at IS NULL ORDER BY id DESC LIMIT $2"
+	rows, err := r.pool.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list operations: %w", err)
+	}
+	defer rows.Close()
+
+	var ops []*model.Operation
+	for rows.Next() {
+		op, err := scanOperation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed scan operation: %w", err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, rows.Err()
+}
+
+func (r *repository) LastUndone(ctx context.Context) (*model.Operation, error) {
+	userID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + operationColumns + " FROM operations WHERE user_id = $1 AND undone_at IS NOT NULL ORDER BY undone_at DESC, id LIMIT 1"
+	op, err := scanOperation(r.pool.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("undone operation %w", model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get operation: %w", err)
+	}
+	return op, nil
+}
+
+func scanOperation(row pgx.Row) (*model.Operation, error) {
+	op := &model.Operation{}
+	var before, after, subtasks []byte
+	err := row.Scan(&op.ID, &op.UserID, &op.Kind, &op.TaskID, &before, &after, &op.Policy, &subtasks, &op.UndoneAt, &op.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if op.Before, err = rep.DecodeTaskImage(before); err != nil {
+		return nil, err
+	}
+	if op.After, err = rep.DecodeTaskImage(after); err != nil {
+		return nil, err
+	}
+	if op.Subtasks, err = rep.DecodeSubtaskImages(subtasks); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.ArchiveRepository = (*archiveRepository)(nil)
+
+type archiveRepository struct {
+	storage *Storage
+	log     zerolog.Logger
+}
+
+func NewArchiveRepository(storage *Storage) *archiveRepository {
+	return &archiveRepository{
+		storage: storage,
+		log:     logger.GetLogger("repository.memory.archive"),
+	}
+}
+
+func (r *archiveRepository) Archive(ctx context.Context, id int) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	task, ok := r.storage.tasks[id]
+	if !ok || !ownedBy(task, ownerID) || task.DeletedAt != nil {
+		return fmt.Errorf("task with id %d %w", id, model.ErrNotFound)
+	}
+
+	// Subtasks in the trash move up too, like the UPDATE of the SQL
+	// backends.
+	now := time.Now()
+	events := []*model.TaskEvent{{TaskID: id, OwnerID: task.OwnerID, ActorID: ownerID, Kind: model.TaskArchived}}
+	for _, child := range r.storage.tasks {
+		if child.ParentID != nil && *child.ParentID == id {
+			before := r.storage.view(child)
+			child.ParentID = cloneInt(task.ParentID)
+			child.UpdatedAt = now
+			events = append(events, model.NewTaskEvent(model.TaskUpdated, ownerID, before, r.storage.view(child)))
+		}
+	}
+	r.storage.archive[id] = &model.ArchivedTask{Task: *cloneTask(task), ArchivedAt: now}
+	delete(r.storage.tasks, id)
+	r.storage.dropDependencies()
+	r.storage.recordEvents(events...)
+
+	r.log.Info().
+		Int("task_id", id).
+		Msg("Task archived")
+	return nil
+}
+
+func (r *archiveRepository) List(ctx context.Context, query string) ([]*model.ArchivedTask, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	query = strings.ToLower(query)
+	var tasks []*model.ArchivedTask
+	for _, archived := range r.storage.archive {
+		if !ownedBy(&archived.Task, ownerID) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(archived.Title), query) &&
+			!strings.Contains(strings.ToLower(archived.Description), query) {
+			continue
+		}
+		tasks = append(tasks, &model.ArchivedTask{Task: *cloneTask(&archived.Task), ArchivedAt: archived.ArchivedAt})
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].ArchivedAt.Equal(tasks[j].ArchivedAt) {
+			return tasks[i].ArchivedAt.After(tasks[j].ArchivedAt)
+		}
+		return tasks[i].ID > tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *archiveRepository) Restore(ctx context.Context, id int) (*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	archived, ok := r.storage.archive[id]
+	if !ok || !ownedBy(&archived.Task, ownerID) {
+		return nil, fmt.Errorf("archived task with id %d %w", id, model.ErrNotFound)
+	}
+
+	task := cloneTask(&archived.Task)
+	if task.ParentID != nil {
+		if _, ok := r.storage.tasks[*task.ParentID]; !ok {
+			task.ParentID = nil
+		}
+	}
+	if task.RecurrenceID != nil {
+		if _, ok := r.storage.recurrences[*task.RecurrenceID]; !ok {
+			task.RecurrenceID = nil
+		}
+	}
+	if task.RecurrenceID != nil && r.storage.hasOccurrence(*task.RecurrenceID, *task.OccurrenceAt) {
+		return nil, fmt.Errorf("task %s %w", task.DisplayID(), model.ErrAlreadyExists)
+	}
+
+	task.UpdatedAt = time.Now()
+	r.storage.tasks[id] = task
+	r.storage.registerTags(task.OwnerID, task.Tags)
+	delete(r.storage.archive, id)
+	r.storage.recordEvents(model.NewTaskEvent(model.TaskRestored, ownerID, &archived.Task, task))
+
+	r.log.Info().
+		Int("task_id", id).
+		Msg("Task restored from archive")
+	return cloneTask(task), nil
+}
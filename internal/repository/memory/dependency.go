@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.DependencyRepository = (*dependencyRepository)(nil)
+
+type dependencyRepository struct {
+	storage *Storage
+	log     zerolog.Logger
+}
+
+func NewDependencyRepository(storage *Storage) *dependencyRepository {
+	return &dependencyRepository{
+		storage: storage,
+		log:     logger.GetLogger("repository.memory.dependency"),
+	}
+}
+
+func (r *dependencyRepository) Add(ctx context.Context, taskID, dependsOnID int) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	for _, id := range []int{taskID, dependsOnID} {
+		if task, ok := r.storage.tasks[id]; !ok || !ownedBy(task, ownerID) {
+			return fmt.Errorf("task with id %d %w", id, model.ErrNotFound)
+		}
+	}
+
+	// The check runs under the write lock, so concurrent adds cannot close
+	// a cycle together.
+	if err := r.storage.graph(ownerID).CheckDependency(taskID, dependsOnID); err != nil {
+		return err
+	}
+	deps := r.storage.dependencies[taskID]
+	if deps == nil {
+		deps = make(map[int]bool)
+		r.storage.dependencies[taskID] = deps
+	}
+	deps[dependsOnID] = true
+
+	r.log.Info().
+		Int("task_id", taskID).
+		Int("depends_on_id", dependsOnID).
+		Msg("Dependency added")
+	return nil
+}
+
+func (r *dependencyRepository) Remove(ctx context.Context, taskID, dependsOnID int) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	task, ok := r.storage.tasks[taskID]
+	if !ok || !ownedBy(task, ownerID) || !r.storage.dependencies[taskID][dependsOnID] {
+		return fmt.Errorf("dependency of task %d on %d %w", taskID, dependsOnID, model.ErrNotFound)
+	}
+	delete(r.storage.dependencies[taskID], dependsOnID)
+
+	r.log.Info().
+		Int("task_id", taskID).
+		Int("depends_on_id", dependsOnID).
+		Msg("Dependency removed")
+	return nil
+}
+
+func (r *dependencyRepository) Graph(ctx context.Context) (model.DependencyGraph, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	return r.storage.graph(ownerID), nil
+}
+
+// graph collects the dependencies between the tasks of an owner. The
+// caller must hold the lock.
+func (s *Storage) graph(ownerID *int) model.DependencyGraph {
+	graph := make(model.DependencyGraph)
+	for taskID, deps := range s.dependencies {
+		if task, ok := s.tasks[taskID]; !ok || !ownedBy(task, ownerID) {
+			continue
+		}
+		for dep := range deps {
+			graph[taskID] = append(graph[taskID], dep)
+		}
+	}
+	return graph
+}
+
+// view copies a stored task for callers and fills the fields the SQL
+// backends compute in queries. The caller must hold the lock.
+func (s *Storage) view(task *model.Task) *model.Task {
+	c := cloneTask(task)
+	c.OpenDependencies = 0
+	for dep := range s.dependencies[task.ID] {
+		if depTask, ok := s.tasks[dep]; ok && depTask.DeletedAt == nil && !depTask.Status.IsFinished() {
+			c.OpenDependencies++
+		}
+	}
+	return c
+}
+
+// dropDependencies forgets the dependencies of deleted tasks, like ON
+// DELETE CASCADE does. The caller must hold the write lock.
+func (s *Storage) dropDependencies() {
+	for taskID, deps := range s.dependencies {
+		if _, ok := s.tasks[taskID]; !ok {
+			delete(s.dependencies, taskID)
+			continue
+		}
+		for dep := range deps {
+			if _, ok := s.tasks[dep]; !ok {
+				delete(deps, dep)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.EventRepository = (*eventRepository)(nil)
+
+type eventRepository struct {
+	storage *Storage
+	log     zerolog.Logger
+}
+
+func NewEventRepository(storage *Storage) *eventRepository {
+	return &eventRepository{
+		storage: storage,
+		log:     logger.GetLogger("repository.memory.event"),
+	}
+}
+
+func (r *eventRepository) History(ctx context.Context, taskID int) ([]*model.TaskEvent, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	var events []*model.TaskEvent
+	for _, event := range r.storage.events {
+		if event.TaskID == taskID && (ownerID == nil || event.OwnerID == *ownerID) {
+			events = append(events, r.storage.eventView(event))
+		}
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("history of task %d %w", taskID, model.ErrNotFound)
+	}
+	return events, nil
+}
+
+func (r *eventRepository) Until(ctx context.Context, at time.Time) ([]*model.TaskEvent, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	var events []*model.TaskEvent
+	for _, event := range r.storage.events {
+		if !event.CreatedAt.After(at) && (ownerID == nil || event.OwnerID == *ownerID) {
+			events = append(events, r.storage.eventView(event))
+		}
+	}
+	return events, nil
+}
+
+// recordEvents appends the events of a change. Updates that change no
+// tracked field are not recorded. The caller must hold the write lock.
+func (s *Storage) recordEvents(events ...*model.TaskEvent) {
+	now := time.Now()
+	for _, event := range events {
+		if event.Kind == model.TaskUpdated && len(event.Changes) == 0 {
+			continue
+		}
+		s.lastEventID++
+		c := *event
+		c.ID = s.lastEventID
+		c.CreatedAt = now
+		s.events = append(s.events, &c)
+	}
+}
+
+// eventView copies a stored event and fills the login of its actor.
+func (s *Storage) eventView(event *model.TaskEvent) *model.TaskEvent {
+	c := *event
+	c.ActorID = cloneInt(event.ActorID)
+	c.Changes = append([]model.FieldChange{}, event.Changes...)
+	c.Actor = "system"
+	if c.ActorID != nil {
+		if user, ok := s.users[*c.ActorID]; ok {
+			c.Actor = user.Login
+		}
+	}
+	return &c
+}
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/auth"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.JournalRepository = (*journalRepository)(nil)
+
+type journalRepository struct {
+	storage *Storage
+	log     zerolog.Logger
+}
+
+func NewJournalRepository(storage *Storage) *journalRepository {
+	return &journalRepository{
+		storage: storage,
+		log:     logger.GetLogger("repository.memory.journal"),
+	}
+}
+
+func (r *journalRepository) Done(ctx context.Context, limit int) ([]*model.Operation, error) {
+	userID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	var ops []*model.Operation
+	for i := len(r.storage.operations) - 1; i >= 0 && len(ops) < limit; i-- {
+		if op := r.storage.operations[i]; op.UserID == userID && op.UndoneAt == nil {
+			ops = append(ops, cloneOperation(op))
+		}
+	}
+	return ops, nil
+}
+
+func (r *journalRepository) LastUndone(ctx context.Context) (*model.Operation, error) {
+	userID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	var last *model.Operation
+	for _, op := range r.storage.operations {
+		if op.UserID != userID || op.UndoneAt == nil {
+			continue
+		}
+		if last == nil || op.UndoneAt.After(*last.UndoneAt) {
+			last = op
+		}
+	}
+	if last == nil {
+		return nil, fmt.Errorf("undone operation %w", model.ErrNotFound)
+	}
+	return cloneOperation(last), nil
+}
+
+// replayed returns the operation an undo or a redo replays, nil when the
+// change is not one. The operation must still be in the state the replay
+// expects, so two concurrent undos cannot both apply it. The caller must
+// hold the write lock and call it before changing anything.
+func (s *Storage) replayed(ctx context.Context) (*model.Operation, error) {
+	replay, ok := rep.ReplayFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	userID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range s.operations {
+		if op.ID == replay.OperationID && op.UserID == userID && (op.UndoneAt == nil) == replay.Undo {
+			return op, nil
+		}
+	}
+	return nil, rep.ReplayConflict(replay)
+}
+
+// journal writes the operation of a change, so the user can undo it. It
+// forgets the undone operations, they cannot be redone after a new change.
+// Undo and redo mark the operation replayed returned instead. The caller
+// must hold the write lock.
+func (s *Storage) journal(ctx context.Context, replayed *model.Operation, op *model.Operation) {
+	if !rep.Journaled(ctx) {
+		markReplayed(ctx, replayed)
+		return
+	}
+	p, _ := auth.FromContext(ctx)
+
+	kept := s.operations[:0]
+	for _, stored := range s.operations {
+		if stored.UserID != p.UserID || stored.UndoneAt == nil {
+			kept = append(kept, stored)
+		}
+	}
+	s.operations = kept
+
+	s.lastOperationID++
+	op.ID, op.UserID, op.TaskID, op.CreatedAt = s.lastOperationID, p.UserID, op.Task().ID, time.Now()
+	s.operations = append(s.operations, cloneOperation(op))
+}
+
+// markReplayed marks the operation replayed returned undone or done again,
+// a nil operation is left alone.
+func markReplayed(ctx context.Context, op *model.Operation) {
+	if op == nil {
+		return
+	}
+	op.UndoneAt = nil
+	if replay, _ := rep.ReplayFromContext(ctx); replay.Undo {
+		now := time.Now()
+		op.UndoneAt = &now
+	}
+}
+
+func cloneOperation(op *model.Operation) *model.Operation {
+	c := *op
+	if op.Before != nil {
+		c.Before = cloneTask(op.Before)
+	}
+	if op.After != nil {
+		c.After = cloneTask(op.After)
+	}
+	c.Subtasks = make([]model.SubtaskChange, 0, len(op.Subtasks))
+	for _, sub := range op.Subtasks {
+		change := model.SubtaskChange{Before: cloneTask(sub.Before)}
+		if sub.After != nil {
+			change.After = cloneTask(sub.After)
+		}
+		c.Subtasks = append(c.Subtasks, change)
+	}
+	c.UndoneAt = cloneTime(op.UndoneAt)
+	return &c
+}
@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.ProjectRepository = (*projectRepository)(nil)
+
+type projectRepository struct {
+	storage *Storage
+	log     zerolog.Logger
+}
+
+func NewProjectRepository(storage *Storage) *projectRepository {
+	return &projectRepository{
+		storage: storage,
+		log:     logger.GetLogger("repository.memory.project"),
+	}
+}
+
+func (r *projectRepository) CreateProject(ctx context.Context, project *model.Project) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if r.storage.findProject(ownerID, project.Key) != nil {
+		return fmt.Errorf("project %q %w", project.Key, model.ErrAlreadyExists)
+	}
+
+	r.storage.lastProjectID++
+	project.ID = r.storage.lastProjectID
+	project.OwnerID = ownerID
+	project.CreatedAt = time.Now()
+
+	stored := *project
+	r.storage.projects[project.ID] = &stored
+
+	r.log.Info().
+		Int("project_id", project.ID).
+		Str("key", project.Key).
+		Msg("Project created")
+	return nil
+}
+
+func (r *projectRepository) GetByKey(ctx context.Context, key string) (*model.Project, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	project := r.storage.findProject(ownerID, key)
+	if project == nil {
+		return nil, fmt.Errorf("project %q %w", key, model.ErrNotFound)
+	}
+	return r.storage.cloneProject(project), nil
+}
+
+func (r *projectRepository) List(ctx context.Context, includeArchived bool) ([]*model.Project, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	var projects []*model.Project
+	for _, project := range r.storage.projects {
+		if project.OwnerID != ownerID || (project.IsArchived() && !includeArchived) {
+			continue
+		}
+		projects = append(projects, r.storage.cloneProject(project))
+	}
+
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Key < projects[j].Key })
+	return projects, nil
+}
+
+func (r *projectRepository) SetArchived(ctx context.Context, key string, archived bool) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	project := r.storage.findProject(ownerID, key)
+	if project == nil {
+		return fmt.Errorf("project %q %w", key, model.ErrNotFound)
+	}
+
+	project.ArchivedAt = nil
+	if archived {
+		now := time.Now()
+		project.ArchivedAt = &now
+	}
+
+	r.log.Info().
+		Str("key", key).
+		Bool("archived", archived).
+		Msg("Project archive state changed")
+	return nil
+}
+
+func (s *Storage) findProject(ownerID int, key string) *model.Project {
+	for _, project := range s.projects {
+		if project.OwnerID == ownerID && project.Key == key {
+			return project
+		}
+	}
+	return nil
+}
+
+// cloneProject copies a project and counts its tasks. The caller must hold
+// the lock.
+func (s *Storage) cloneProject(project *model.Project) *model.Project {
+	c := *project
+	c.ArchivedAt = cloneTime(project.ArchivedAt)
+	c.Tasks = 0
+	for _, task := range s.tasks {
+		if task.ProjectID != nil && *task.ProjectID == project.ID && task.DeletedAt == nil {
+			c.Tasks++
+		}
+	}
+	return &c
+}
+
+// assignNumber takes the next number of task.ProjectID, which has to belong
+// to ownerID, and fills task.Number and task.ProjectKey. The caller must
+// hold the write lock.
+func (s *Storage) assignNumber(task *model.Task, ownerID int) error {
+	project, ok := s.projects[*task.ProjectID]
+	if !ok || project.OwnerID != ownerID {
+		return fmt.Errorf("project with id %d %w", *task.ProjectID, model.ErrNotFound)
+	}
+
+	s.projectNumbers[project.ID]++
+	task.ProjectKey = project.Key
+	task.Number = s.projectNumbers[project.ID]
+	return nil
+}
+
+// inArchivedProject reports whether the task belongs to an archived
+// project. The caller must hold the lock.
+func (s *Storage) inArchivedProject(task *model.Task) bool {
+	if task.ProjectID == nil {
+		return false
+	}
+	project, ok := s.projects[*task.ProjectID]
+	return ok && project.IsArchived()
+}
@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.RecurrenceRepository = (*recurrenceRepository)(nil)
+
+type recurrenceRepository struct {
+	storage *Storage
+	log     zerolog.Logger
+}
+
+func NewRecurrenceRepository(storage *Storage) *recurrenceRepository {
+	return &recurrenceRepository{
+		storage: storage,
+		log:     logger.GetLogger("repository.memory.recurrence"),
+	}
+}
+
+func (r *recurrenceRepository) CreateRecurrence(ctx context.Context, recurrence *model.Recurrence) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	r.storage.lastRecurrenceID++
+	recurrence.ID = r.storage.lastRecurrenceID
+	recurrence.OwnerID = ownerID
+	recurrence.CreatedAt = time.Now()
+	r.storage.recurrences[recurrence.ID] = cloneRecurrence(recurrence)
+
+	r.log.Info().
+		Int("recurrence_id", recurrence.ID).
+		Str("rule", recurrence.Rule).
+		Time("next_at", recurrence.NextAt).
+		Msg("Recurrence created")
+	return nil
+}
+
+func (r *recurrenceRepository) List(ctx context.Context) ([]*model.Recurrence, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.find(func(recurrence *model.Recurrence) bool {
+		return recurrence.OwnerID == ownerID
+	}), nil
+}
+
+func (r *recurrenceRepository) DeleteRecurrence(ctx context.Context, id int) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	recurrence, ok := r.storage.recurrences[id]
+	if !ok || recurrence.OwnerID != ownerID {
+		return fmt.Errorf("recurrence with id %d %w", id, model.ErrNotFound)
+	}
+	delete(r.storage.recurrences, id)
+	for _, task := range r.storage.tasks {
+		if task.RecurrenceID != nil && *task.RecurrenceID == id {
+			task.RecurrenceID = nil
+		}
+	}
+
+	r.log.Info().
+		Int("recurrence_id", id).
+		Msg("Recurrence deleted")
+	return nil
+}
+
+func (r *recurrenceRepository) Due(ctx context.Context, now time.Time) ([]*model.Recurrence, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.find(func(recurrence *model.Recurrence) bool {
+		if ownerID != nil && recurrence.OwnerID != *ownerID {
+			return false
+		}
+		if recurrence.ProjectID != nil {
+			if project, ok := r.storage.projects[*recurrence.ProjectID]; ok && project.IsArchived() {
+				return false
+			}
+		}
+		return !recurrence.NextAt.After(now) || !r.storage.hasOpenOccurrence(recurrence.ID)
+	}), nil
+}
+
+func (r *recurrenceRepository) Advance(ctx context.Context, id int, from, to time.Time) (bool, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	recurrence, ok := r.storage.recurrences[id]
+	if !ok || (ownerID != nil && recurrence.OwnerID != *ownerID) || !recurrence.NextAt.Equal(from) {
+		return false, nil
+	}
+	recurrence.NextAt = to
+	return true, nil
+}
+
+// find returns copies of the matching recurrences, soonest first.
+func (r *recurrenceRepository) find(match func(*model.Recurrence) bool) []*model.Recurrence {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	var recurrences []*model.Recurrence
+	for _, recurrence := range r.storage.recurrences {
+		if !match(recurrence) {
+			continue
+		}
+		c := cloneRecurrence(recurrence)
+		if c.ProjectID != nil {
+			if project, ok := r.storage.projects[*c.ProjectID]; ok {
+				c.ProjectKey = project.Key
+			}
+		}
+		recurrences = append(recurrences, c)
+	}
+
+	sort.Slice(recurrences, func(i, j int) bool {
+		if !recurrences[i].NextAt.Equal(recurrences[j].NextAt) {
+			return recurrences[i].NextAt.Before(recurrences[j].NextAt)
+		}
+		return recurrences[i].ID < recurrences[j].ID
+	})
+	return recurrences
+}
+
+// hasOccurrence reports whether the task for the occurrence of a
+// recurrence at at exists. The caller must hold the lock.
+func (s *Storage) hasOccurrence(recurrenceID int, at time.Time) bool {
+	for _, task := range s.tasks {
+		if task.RecurrenceID != nil && *task.RecurrenceID == recurrenceID && task.OccurrenceAt.Equal(at) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasOpenOccurrence reports whether a recurrence has an unfinished task.
+// The caller must hold the lock.
+func (s *Storage) hasOpenOccurrence(recurrenceID int) bool {
+	for _, task := range s.tasks {
+		if task.RecurrenceID != nil && *task.RecurrenceID == recurrenceID && task.DeletedAt == nil && !task.Status.IsFinished() {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneRecurrence(recurrence *model.Recurrence) *model.Recurrence {
+	c := *recurrence
+	c.Tags = append([]string{}, recurrence.Tags...)
+	c.ProjectID = cloneInt(recurrence.ProjectID)
+	return &c
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+)
+
+var _ rep.SessionRepository = (*sessionRepository)(nil)
+
+type sessionRepository struct {
+	storage *Storage
+}
+
+func NewSessionRepository(storage *Storage) *sessionRepository {
+	return &sessionRepository{
+		storage: storage,
+	}
+}
+
+func (r *sessionRepository) CreateSession(_ context.Context, session *model.Session) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.users[session.UserID]; !ok {
+		return fmt.Errorf("user with id %d %w", session.UserID, model.ErrNotFound)
+	}
+
+	session.CreatedAt = time.Now()
+	stored := *session
+	r.storage.sessions[session.TokenHash] = &stored
+	return nil
+}
+
+func (r *sessionRepository) GetByTokenHash(_ context.Context, tokenHash string) (*model.Session, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	session, ok := r.storage.sessions[tokenHash]
+	if !ok {
+		return nil, fmt.Errorf("session %w", model.ErrNotFound)
+	}
+
+	found := *session
+	return &found, nil
+}
+
+func (r *sessionRepository) DeleteSession(_ context.Context, tokenHash string) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	delete(r.storage.sessions, tokenHash)
+	return nil
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"sync"
+	"techno/internal/model"
+)
+
+// Storage holds the data of every in-memory repository. Repositories built
+// on the same Storage see each other's writes, like tables of one database.
+type Storage struct {
+	mu sync.RWMutex
+
+	tasks      map[int]*model.Task
+	lastTaskID int
+
+	// archive keeps archived tasks under their original ids.
+	archive map[int]*model.ArchivedTask
+
+	// tags registers tag names per owner, tasks keep the names they carry.
+	tags      map[int]*model.Tag
+	lastTagID int
+
+	projects      map[int]*model.Project
+	lastProjectID int
+	// projectNumbers is the last task number given out per project.
+	projectNumbers map[int]int
+
+	recurrences      map[int]*model.Recurrence
+	lastRecurrenceID int
+
+	// dependencies maps a task id to the set of task ids it depends on.
+	dependencies map[int]map[int]bool
+
+	// operations is the task journal, oldest first.
+	operations      []*model.Operation
+	lastOperationID int
+
+	// events is the audit trail of task changes, oldest first.
+	events      []*model.TaskEvent
+	lastEventID int
+
+	users      map[int]*model.User
+	lastUserID int
+
+	sessions map[string]*model.Session
+}
+
+func NewStorage() *Storage {
+	return &Storage{
+		tasks:    make(map[int]*model.Task),
+		archive:  make(map[int]*model.ArchivedTask),
+		tags:     make(map[int]*model.Tag),
+		projects: make(map[int]*model.Project),
+
+		recurrences:    make(map[int]*model.Recurrence),
+		projectNumbers: make(map[int]int),
+		dependencies:   make(map[int]map[int]bool),
+		users:          make(map[int]*model.User),
+		sessions:       make(map[string]*model.Session),
+	}
+}
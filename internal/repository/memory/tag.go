@@ -0,0 +1,199 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.TagRepository = (*tagRepository)(nil)
+
+type tagRepository struct {
+	storage *Storage
+	log     zerolog.Logger
+}
+
+func NewTagRepository(storage *Storage) *tagRepository {
+	return &tagRepository{
+		storage: storage,
+		log:     logger.GetLogger("repository.memory.tag"),
+	}
+}
+
+func (r *tagRepository) List(ctx context.Context) ([]*model.Tag, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	var tags []*model.Tag
+	for _, tag := range r.storage.tags {
+		if tag.OwnerID != ownerID {
+			continue
+		}
+
+		found := *tag
+		for _, task := range r.storage.tasks {
+			if task.OwnerID == ownerID && task.DeletedAt == nil && task.HasTag(tag.Name) {
+				found.Tasks++
+			}
+		}
+		tags = append(tags, &found)
+	}
+
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+func (r *tagRepository) Rename(ctx context.Context, from, to string) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	tag := r.storage.findTag(ownerID, from)
+	if tag == nil {
+		return fmt.Errorf("tag %q %w", from, model.ErrNotFound)
+	}
+	if r.storage.findTag(ownerID, to) != nil {
+		return fmt.Errorf("tag %q %w, merge the tags instead", to, model.ErrAlreadyExists)
+	}
+
+	tag.Name = to
+	r.storage.retag(ownerID, []string{from}, to)
+
+	r.log.Info().
+		Int("owner_id", ownerID).
+		Str("from", from).
+		Str("to", to).
+		Msg("Tag renamed")
+	return nil
+}
+
+func (r *tagRepository) Merge(ctx context.Context, sources []string, target string) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	for _, source := range sources {
+		if r.storage.findTag(ownerID, source) == nil {
+			return fmt.Errorf("tag %w, check the names with `tag list`", model.ErrNotFound)
+		}
+	}
+
+	r.storage.registerTags(ownerID, []string{target})
+	r.storage.retag(ownerID, sources, target)
+	for id, tag := range r.storage.tags {
+		if tag.OwnerID == ownerID && tag.Name != target && contains(sources, tag.Name) {
+			delete(r.storage.tags, id)
+		}
+	}
+
+	r.log.Info().
+		Int("owner_id", ownerID).
+		Strs("sources", sources).
+		Str("target", target).
+		Msg("Tags merged")
+	return nil
+}
+
+func (r *tagRepository) Delete(ctx context.Context, name string) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	tag := r.storage.findTag(ownerID, name)
+	if tag == nil {
+		return fmt.Errorf("tag %q %w", name, model.ErrNotFound)
+	}
+
+	delete(r.storage.tags, tag.ID)
+	r.storage.retag(ownerID, []string{name}, "")
+
+	r.log.Info().
+		Int("owner_id", ownerID).
+		Str("name", name).
+		Msg("Tag deleted")
+	return nil
+}
+
+// registerTags adds the names the owner does not have yet. The caller must
+// hold the write lock.
+func (s *Storage) registerTags(ownerID int, names []string) {
+	for _, name := range names {
+		if s.findTag(ownerID, name) != nil {
+			continue
+		}
+		s.lastTagID++
+		s.tags[s.lastTagID] = &model.Tag{ID: s.lastTagID, OwnerID: ownerID, Name: name}
+	}
+}
+
+func (s *Storage) findTag(ownerID int, name string) *model.Tag {
+	for _, tag := range s.tags {
+		if tag.OwnerID == ownerID && tag.Name == name {
+			return tag
+		}
+	}
+	return nil
+}
+
+// retag replaces sources with target on every task, archived task and
+// recurrence of the owner, an empty target only removes them, and records an update event for
+// every retagged task. The caller must hold the write lock.
+func (s *Storage) retag(ownerID int, sources []string, target string) {
+	var events []*model.TaskEvent
+	for _, task := range s.tasks {
+		if task.OwnerID != ownerID {
+			continue
+		}
+		tags, changed := model.Retag(task.Tags, sources, target)
+		if !changed {
+			continue
+		}
+		before := s.view(task)
+		task.Tags = tags
+		events = append(events, model.NewTaskEvent(model.TaskUpdated, &ownerID, before, s.view(task)))
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].TaskID < events[j].TaskID })
+	s.recordEvents(events...)
+
+	for _, recurrence := range s.recurrences {
+		if recurrence.OwnerID == ownerID {
+			recurrence.Tags, _ = model.Retag(recurrence.Tags, sources, target)
+		}
+	}
+	for _, archived := range s.archive {
+		if archived.OwnerID == ownerID {
+			archived.Tags, _ = model.Retag(archived.Tags, sources, target)
+		}
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,465 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.TaskRepository = (*taskRepository)(nil)
+
+type taskRepository struct {
+	storage *Storage
+	log     zerolog.Logger
+}
+
+func NewTaskRepository(storage *Storage) *taskRepository {
+	return &taskRepository{
+		storage: storage,
+		log:     logger.GetLogger("repository.memory.task"),
+	}
+}
+
+func (r *taskRepository) CreateTask(ctx context.Context, task *model.Task) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+	if ownerID != nil {
+		task.OwnerID = *ownerID
+	}
+	if task.OwnerID <= 0 {
+		return errors.New("task owner is required")
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	replayed, err := r.storage.replayed(ctx)
+	if err != nil {
+		return err
+	}
+	if task.RecurrenceID != nil && r.storage.hasOccurrence(*task.RecurrenceID, *task.OccurrenceAt) {
+		return fmt.Errorf("occurrence of recurrence %d at %s %w", *task.RecurrenceID, task.OccurrenceAt.Format(time.RFC3339), model.ErrAlreadyExists)
+	}
+	if task.ProjectID != nil {
+		if err := r.storage.assignNumber(task, task.OwnerID); err != nil {
+			return err
+		}
+	}
+
+	r.storage.lastTaskID++
+	task.ID = r.storage.lastTaskID
+	task.Status = model.Open
+	task.CreatedAt = time.Now()
+	task.UpdatedAt = task.CreatedAt
+
+	r.storage.tasks[task.ID] = cloneTask(task)
+	r.storage.registerTags(task.OwnerID, task.Tags)
+	// The owner scope is the user making the change, nil for the system.
+	created := r.storage.view(task)
+	r.storage.recordEvents(model.NewTaskEvent(model.TaskCreated, ownerID, nil, created))
+	r.storage.journal(ctx, replayed, &model.Operation{Kind: model.OperationCreate, After: created})
+
+	r.log.Info().
+		Int("task_id", task.ID).
+		Str("title", task.Title).
+		Msg("Task created successfull")
+	return nil
+}
+
+func (r *taskRepository) GetByID(ctx context.Context, id int) (*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	task, ok := r.storage.tasks[id]
+	if !ok || !ownedBy(task, ownerID) || task.DeletedAt != nil {
+		return nil, fmt.Errorf("task with id %d %w", id, model.ErrNotFound)
+	}
+
+	return r.storage.view(task), nil
+}
+
+func (r *taskRepository) GetAll(ctx context.Context) ([]*model.Task, error) {
+	return r.find(ctx, func(*model.Task) bool { return true })
+}
+
+func (r *taskRepository) GetByStatus(ctx context.Context, status model.TaskStatus) ([]*model.Task, error) {
+	return r.find(ctx, func(task *model.Task) bool { return task.Status == status })
+}
+
+func (r *taskRepository) List(ctx context.Context, filter model.TaskFilter) ([]*model.Task, error) {
+	now := time.Now()
+	tasks, err := r.find(ctx, func(task *model.Task) bool {
+		if filter.Project == "" && r.storage.inArchivedProject(task) {
+			return false
+		}
+		return filter.Match(task, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	model.SortTasks(tasks, filter.Sort)
+	return tasks, nil
+}
+
+func (r *taskRepository) GetByProjectNumber(ctx context.Context, projectKey string, number int) (*model.Task, error) {
+	tasks, err := r.find(ctx, func(task *model.Task) bool {
+		return task.ProjectKey == projectKey && task.Number == number
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("task %s-%d %w", projectKey, number, model.ErrNotFound)
+	}
+	return tasks[0], nil
+}
+
+func (r *taskRepository) GetSubtasks(ctx context.Context, id int) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	var tasks []*model.Task
+	if parent, ok := r.storage.tasks[id]; ok && ownedBy(parent, ownerID) && parent.DeletedAt == nil {
+		for _, task := range r.storage.subtree(id) {
+			tasks = append(tasks, r.storage.view(task))
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+			return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *taskRepository) UpdateTask(ctx context.Context, task *model.Task, expected *model.Task) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	stored, ok := r.storage.tasks[task.ID]
+	if !ok || !ownedBy(stored, ownerID) || stored.DeletedAt != nil {
+		return fmt.Errorf("task with id %d %w", task.ID, model.ErrNotFound)
+	}
+	before := r.storage.view(stored)
+	if expected != nil && !model.SameState(before, expected) {
+		return fmt.Errorf("%w: task %d was changed since it was read", model.ErrConflict, task.ID)
+	}
+	replayed, err := r.storage.replayed(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A task keeps its number while it stays in the same project and gets
+	// the next number of the project it moves to.
+	switch {
+	case task.ProjectID == nil:
+		task.ProjectKey, task.Number = "", 0
+	case stored.ProjectID == nil || *stored.ProjectID != *task.ProjectID:
+		if err := r.storage.assignNumber(task, stored.OwnerID); err != nil {
+			return err
+		}
+	default:
+		task.ProjectKey, task.Number = stored.ProjectKey, stored.Number
+	}
+
+	stored.ParentID = cloneInt(task.ParentID)
+	stored.ProjectID = cloneInt(task.ProjectID)
+	stored.ProjectKey = task.ProjectKey
+	stored.Number = task.Number
+	stored.Title = task.Title
+	stored.Description = task.Description
+	stored.Status = task.Status
+	stored.Priority = task.Priority
+	stored.DueAt = cloneTime(task.DueAt)
+	stored.Tags = append([]string{}, task.Tags...)
+	r.storage.registerTags(stored.OwnerID, stored.Tags)
+
+	// CompletedAt keeps the first finish time while a task moves between
+	// finished statuses and is cleared when it is reopened.
+	now := time.Now()
+	stored.UpdatedAt = now
+	switch {
+	case !stored.Status.IsFinished():
+		stored.CompletedAt = nil
+	case stored.CompletedAt == nil:
+		stored.CompletedAt = &now
+	}
+	task.UpdatedAt = stored.UpdatedAt
+	task.CompletedAt = cloneTime(stored.CompletedAt)
+	after := r.storage.view(stored)
+	r.storage.recordEvents(model.NewTaskEvent(model.TaskUpdated, ownerID, before, after))
+	r.storage.journal(ctx, replayed, &model.Operation{Kind: model.OperationUpdate, Before: before, After: after})
+
+	r.log.Info().
+		Int("task_id", task.ID).
+		Str("status", task.Status.StringStatus()).
+		Msg("Task updated successfull")
+	return nil
+}
+
+func (r *taskRepository) DeleteTask(ctx context.Context, id int, policy model.ChildPolicy, expected *model.Task, subtasks []*model.Task) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	task, ok := r.storage.tasks[id]
+	if !ok || !ownedBy(task, ownerID) || task.DeletedAt != nil {
+		return fmt.Errorf("task with id %d %w", id, model.ErrNotFound)
+	}
+	before := r.storage.view(task)
+	if expected != nil && !model.SameState(before, expected) {
+		return fmt.Errorf("%w: task %d was changed since it was read", model.ErrConflict, id)
+	}
+	replayed, err := r.storage.replayed(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The journal keeps the images of the subtasks the policy touches and
+	// a redo has to find the same ones.
+	children := r.storage.children(id)
+	var touched []*model.Task
+	switch {
+	case len(children) == 0:
+	case policy == model.ChildrenCascade:
+		touched = r.storage.subtree(id)
+	case policy == model.ChildrenReparent:
+		touched = children
+	default:
+		return fmt.Errorf("task %d has %d subtask(s): %w", id, len(children), model.ErrHasSubtasks)
+	}
+	sort.Slice(touched, func(i, j int) bool { return touched[i].ID < touched[j].ID })
+	images := make([]*model.Task, 0, len(touched))
+	for _, sub := range touched {
+		images = append(images, r.storage.view(sub))
+	}
+	if expected != nil && !model.SameTasks(images, subtasks) {
+		return fmt.Errorf("%w: subtasks of task %d were changed since it was deleted", model.ErrConflict, id)
+	}
+
+	// Deleting moves tasks to the trash. A cascade shares one DeletedAt.
+	now := time.Now()
+	op := &model.Operation{Kind: model.OperationDelete, Before: before, Policy: policy}
+	deleted := []*model.Task{task}
+	var events []*model.TaskEvent
+	if policy == model.ChildrenReparent {
+		for i, child := range touched {
+			child.ParentID = cloneInt(task.ParentID)
+			child.UpdatedAt = now
+			after := r.storage.view(child)
+			op.Subtasks = append(op.Subtasks, model.SubtaskChange{Before: images[i], After: after})
+			events = append(events, model.NewTaskEvent(model.TaskUpdated, ownerID, images[i], after))
+		}
+	} else {
+		for i, sub := range touched {
+			op.Subtasks = append(op.Subtasks, model.SubtaskChange{Before: images[i]})
+			deleted = append(deleted, sub)
+		}
+	}
+	for _, sub := range deleted {
+		sub.DeletedAt = &now
+		events = append(events, &model.TaskEvent{TaskID: sub.ID, OwnerID: sub.OwnerID, ActorID: ownerID, Kind: model.TaskDeleted})
+	}
+	r.storage.recordEvents(events...)
+	r.storage.journal(ctx, replayed, op)
+
+	r.log.Info().
+		Int("task_id", id).
+		Str("children", policy.String()).
+		Msg("Task deleted successfully")
+	return nil
+}
+
+func (r *taskRepository) UndeleteTask(ctx context.Context, op *model.Operation) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	task, ok := r.storage.tasks[op.TaskID]
+	if !ok || !ownedBy(task, ownerID) || task.DeletedAt == nil {
+		return fmt.Errorf("deleted task with id %d %w", op.TaskID, model.ErrNotFound)
+	}
+	if task.ParentID != nil {
+		if parent, ok := r.storage.tasks[*task.ParentID]; ok && parent.DeletedAt != nil {
+			return fmt.Errorf("%w: the parent of task %d is in the trash", model.ErrConflict, op.TaskID)
+		}
+	}
+	deleted := r.storage.view(task)
+	if !model.SameState(deleted, op.Before) {
+		return fmt.Errorf("%w: task %d was changed since it was deleted", model.ErrConflict, op.TaskID)
+	}
+	replayed, err := r.storage.replayed(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Every subtask must still be where the delete left it: in the trash,
+	// or live under the new parent.
+	restored := []*model.Task{task}
+	var moved []*model.Task
+	for _, sub := range op.Subtasks {
+		current, ok := r.storage.tasks[sub.Before.ID]
+		if !ok {
+			return fmt.Errorf("%w: subtask %d no longer exists", model.ErrConflict, sub.Before.ID)
+		}
+		if sub.After == nil {
+			if current.DeletedAt == nil || !model.SameState(r.storage.view(current), sub.Before) {
+				return fmt.Errorf("%w: subtask %d was changed since it was deleted", model.ErrConflict, current.ID)
+			}
+			restored = append(restored, current)
+			continue
+		}
+		if current.DeletedAt != nil || !model.SameState(r.storage.view(current), sub.After) {
+			return fmt.Errorf("%w: subtask %d was changed since it was moved", model.ErrConflict, current.ID)
+		}
+		moved = append(moved, current)
+	}
+
+	now := time.Now()
+	var events []*model.TaskEvent
+	for _, sub := range restored {
+		sub.DeletedAt = nil
+		sub.UpdatedAt = now
+	}
+	tasks := make([]*model.Task, 0, len(restored))
+	for _, sub := range restored {
+		tasks = append(tasks, r.storage.view(sub))
+	}
+	events = append(events, rep.RestoreEvents(ownerID, deleted, tasks)...)
+	for _, child := range moved {
+		before := r.storage.view(child)
+		child.ParentID = cloneInt(&op.TaskID)
+		child.UpdatedAt = now
+		events = append(events, model.NewTaskEvent(model.TaskUpdated, ownerID, before, r.storage.view(child)))
+	}
+	r.storage.recordEvents(events...)
+	markReplayed(ctx, replayed)
+
+	r.log.Info().
+		Int("task_id", op.TaskID).
+		Int("restored", len(restored)).
+		Int("moved_back", len(moved)).
+		Msg("Task delete undone")
+	return nil
+}
+
+func (r *taskRepository) find(ctx context.Context, match func(*model.Task) bool) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	var tasks []*model.Task
+	for _, task := range r.storage.tasks {
+		if !ownedBy(task, ownerID) || task.DeletedAt != nil || !match(task) {
+			continue
+		}
+		tasks = append(tasks, r.storage.view(task))
+	}
+
+	// Same order as "ORDER BY created_at DESC" in Postgres, with the id as a
+	// tie breaker because several tasks can share a timestamp here.
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+			return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+		}
+		return tasks[i].ID > tasks[j].ID
+	})
+
+	return tasks, nil
+}
+
+// children returns the stored direct subtasks of a task that are not in the
+// trash. The caller must hold the lock.
+func (s *Storage) children(id int) []*model.Task {
+	var children []*model.Task
+	for _, task := range s.tasks {
+		if task.ParentID != nil && *task.ParentID == id && task.DeletedAt == nil {
+			children = append(children, task)
+		}
+	}
+	return children
+}
+
+// subtree returns every stored descendant of a task. The caller must hold
+// the lock.
+func (s *Storage) subtree(id int) []*model.Task {
+	var tasks []*model.Task
+	for queue := s.children(id); len(queue) > 0; queue = queue[1:] {
+		tasks = append(tasks, queue[0])
+		queue = append(queue, s.children(queue[0].ID)...)
+	}
+	return tasks
+}
+
+func ownedBy(task *model.Task, ownerID *int) bool {
+	return ownerID == nil || task.OwnerID == *ownerID
+}
+
+// cloneTask copies a task so callers never share memory with the storage.
+func cloneTask(task *model.Task) *model.Task {
+	c := *task
+	c.DueAt = cloneTime(task.DueAt)
+	c.Tags = append([]string{}, task.Tags...)
+	c.ProjectID = cloneInt(task.ProjectID)
+	c.ParentID = cloneInt(task.ParentID)
+	c.RecurrenceID = cloneInt(task.RecurrenceID)
+	c.OccurrenceAt = cloneTime(task.OccurrenceAt)
+	c.CompletedAt = cloneTime(task.CompletedAt)
+	c.DeletedAt = cloneTime(task.DeletedAt)
+	return &c
+}
+
+func cloneInt(v *int) *int {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
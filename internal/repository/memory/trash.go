@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.TrashRepository = (*trashRepository)(nil)
+
+type trashRepository struct {
+	storage *Storage
+	log     zerolog.Logger
+}
+
+func NewTrashRepository(storage *Storage) *trashRepository {
+	return &trashRepository{
+		storage: storage,
+		log:     logger.GetLogger("repository.memory.trash"),
+	}
+}
+
+func (r *trashRepository) List(ctx context.Context) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	var tasks []*model.Task
+	for _, task := range r.storage.tasks {
+		if ownedBy(task, ownerID) && task.DeletedAt != nil {
+			tasks = append(tasks, r.storage.view(task))
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].DeletedAt.Equal(*tasks[j].DeletedAt) {
+			return tasks[i].DeletedAt.After(*tasks[j].DeletedAt)
+		}
+		return tasks[i].ID > tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *trashRepository) Restore(ctx context.Context, id int) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	task, ok := r.storage.tasks[id]
+	if !ok || !ownedBy(task, ownerID) || task.DeletedAt == nil {
+		return nil, fmt.Errorf("deleted task with id %d %w", id, model.ErrNotFound)
+	}
+	replayed, err := r.storage.replayed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deleted := r.storage.view(task)
+	if task.ParentID != nil {
+		if parent, ok := r.storage.tasks[*task.ParentID]; ok && parent.DeletedAt != nil {
+			task.ParentID = nil
+		}
+	}
+
+	// Subtasks deleted by the same cascade carry the same DeletedAt.
+	now := time.Now()
+	batch := []*model.Task{task}
+	for queue := batch; len(queue) > 0; queue = queue[1:] {
+		for _, sub := range r.storage.tasks {
+			if sub.ParentID != nil && *sub.ParentID == queue[0].ID && sub.DeletedAt != nil && sub.DeletedAt.Equal(*task.DeletedAt) {
+				batch = append(batch, sub)
+				queue = append(queue, sub)
+			}
+		}
+	}
+	for _, restored := range batch {
+		restored.DeletedAt = nil
+		restored.UpdatedAt = now
+	}
+
+	var subtasks []*model.Task
+	for _, sub := range r.storage.subtree(id) {
+		subtasks = append(subtasks, r.storage.view(sub))
+	}
+	sort.Slice(subtasks, func(i, j int) bool {
+		if !subtasks[i].CreatedAt.Equal(subtasks[j].CreatedAt) {
+			return subtasks[i].CreatedAt.Before(subtasks[j].CreatedAt)
+		}
+		return subtasks[i].ID < subtasks[j].ID
+	})
+	tasks := append([]*model.Task{r.storage.view(task)}, subtasks...)
+	r.storage.recordEvents(rep.RestoreEvents(ownerID, deleted, tasks)...)
+	markReplayed(ctx, replayed)
+
+	r.log.Info().
+		Int("task_id", id).
+		Int("restored", len(batch)).
+		Msg("Task restored from trash")
+	return tasks, nil
+}
+
+func (r *trashRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	purged := make(map[int]bool)
+	for id, task := range r.storage.tasks {
+		if ownedBy(task, ownerID) && task.DeletedAt != nil && task.DeletedAt.Before(before) {
+			purged[id] = true
+		}
+	}
+	for id := range purged {
+		delete(r.storage.tasks, id)
+	}
+	// Tasks deleted later than their parent stay in the trash without it.
+	for _, task := range r.storage.tasks {
+		if task.ParentID != nil && purged[*task.ParentID] {
+			task.ParentID = nil
+		}
+	}
+	r.storage.dropDependencies()
+
+	r.log.Info().
+		Int("purged", len(purged)).
+		Time("before", before).
+		Msg("Trash purged")
+	return len(purged), nil
+}
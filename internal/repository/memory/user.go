@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+)
+
+var _ rep.UserRepository = (*userRepository)(nil)
+
+type userRepository struct {
+	storage *Storage
+}
+
+func NewUserRepository(storage *Storage) *userRepository {
+	return &userRepository{
+		storage: storage,
+	}
+}
+
+func (r *userRepository) CreateUser(_ context.Context, user *model.User) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	for _, existing := range r.storage.users {
+		if existing.Login == user.Login {
+			return fmt.Errorf("user %q %w", user.Login, model.ErrAlreadyExists)
+		}
+	}
+
+	r.storage.lastUserID++
+	user.ID = r.storage.lastUserID
+	user.CreatedAt = time.Now()
+
+	stored := *user
+	r.storage.users[user.ID] = &stored
+	return nil
+}
+
+func (r *userRepository) GetByID(_ context.Context, id int) (*model.User, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	user, ok := r.storage.users[id]
+	if !ok {
+		return nil, fmt.Errorf("user with id %d %w", id, model.ErrNotFound)
+	}
+
+	found := *user
+	return &found, nil
+}
+
+func (r *userRepository) GetByLogin(_ context.Context, login string) (*model.User, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	for _, user := range r.storage.users {
+		if user.Login == login {
+			found := *user
+			return &found, nil
+		}
+	}
+	return nil, fmt.Errorf("user %q %w", login, model.ErrNotFound)
+}
+
+func (r *userRepository) UpdateTimezone(_ context.Context, id int, timezone string) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	user, ok := r.storage.users[id]
+	if !ok {
+		return fmt.Errorf("user with id %d %w", id, model.ErrNotFound)
+	}
+
+	user.Timezone = timezone
+	return nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"strings"
+	"techno/internal/model"
+)
+
+// OrderBy builds the ORDER BY list of a task listing for the SQL backends.
+// Tasks without a due date always come last, and ties fall back to the
+// newest task first.
+func OrderBy(keys []model.SortKey) string {
+	terms := make([]string, 0, len(keys)+2)
+	hasCreated := false
+
+	for _, key := range keys {
+		switch key.Field {
+		case model.SortByPriority:
+			terms = append(terms, "priority "+direction("DESC", key.Reverse))
+		case model.SortByDue:
+			terms = append(terms, "due_at "+direction("ASC", key.Reverse)+" NULLS LAST")
+		case model.SortByCreated:
+			terms = append(terms, "created_at "+direction("DESC", key.Reverse))
+			hasCreated = true
+		}
+	}
+
+	if !hasCreated {
+		terms = append(terms, "created_at DESC")
+	}
+	terms = append(terms, "id DESC")
+
+	return strings.Join(terms, ", ")
+}
+
+func direction(natural string, reverse bool) string {
+	if !reverse {
+		return natural
+	}
+	if natural == "ASC" {
+		return "DESC"
+	}
+	return "ASC"
+}
@@ -0,0 +1,146 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+const (
+	uniqueViolationCode = "23505"
+	projectColumns      = "id, owner_id, key, name, description, archived_at, created_at, (SELECT COUNT(*) FROM tasks t WHERE t.project_id = projects.id AND t.deleted_at IS NULL)"
+)
+
+var _ rep.ProjectRepository = (*repository)(nil)
+
+type repository struct {
+	pool *pgxpool.Pool
+	log  zerolog.Logger
+}
+
+func NewRepository(pool *pgxpool.Pool) *repository {
+	return &repository{
+		pool: pool,
+		log:  logger.GetLogger("repository.project"),
+	}
+}
+
+func (r *repository) CreateProject(ctx context.Context, project *model.Project) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+	project.OwnerID = ownerID
+
+	query := "INSERT INTO projects (owner_id, key, name, description) VALUES ($1, $2, $3, $4) RETURNING id, created_at"
+	err = r.pool.QueryRow(ctx, query, project.OwnerID, project.Key, project.Name, project.Description).Scan(&project.ID, &project.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return fmt.Errorf("project %q %w", project.Key, model.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	r.log.Info().
+		Int("project_id", project.ID).
+		Str("key", project.Key).
+		Msg("Project created")
+	return nil
+}
+
+func (r *repository) GetByKey(ctx context.Context, key string) (*model.Project, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + projectColumns + " FROM projects WHERE owner_id = $1 AND key = $2"
+
+	project, err := scanProject(r.pool.QueryRow(ctx, query, ownerID, key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("project %q %w", key, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
+}
+
+func (r *repository) List(ctx context.Context, includeArchived bool) ([]*model.Project, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + projectColumns + " FROM projects WHERE owner_id = $1 AND ($2 OR archived_at IS NULL) ORDER BY key"
+	rows, err := r.pool.Query(ctx, query, ownerID, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []*model.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
+
+func (r *repository) SetArchived(ctx context.Context, key string, archived bool) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	var archivedAt *time.Time
+	if archived {
+		now := time.Now()
+		archivedAt = &now
+	}
+
+	result, err := r.pool.Exec(ctx, "UPDATE projects SET archived_at = $1 WHERE owner_id = $2 AND key = $3", archivedAt, ownerID, key)
+	if err != nil {
+		return fmt.Errorf("failed to archive project: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("project %q %w", key, model.ErrNotFound)
+	}
+
+	r.log.Info().
+		Str("key", key).
+		Bool("archived", archived).
+		Msg("Project archive state changed")
+	return nil
+}
+
+func scanProject(row pgx.Row) (*model.Project, error) {
+	project := &model.Project{}
+	err := row.Scan(
+		&project.ID,
+		&project.OwnerID,
+		&project.Key,
+		&project.Name,
+		&project.Description,
+		&project.ArchivedAt,
+		&project.CreatedAt,
+		&project.Tasks,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
@@ -0,0 +1,170 @@
+package recurrence
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+const recurrenceColumns = "id, owner_id, rule, timezone, title, description, priority, tags, project_id, " +
+	"COALESCE((SELECT key FROM projects p WHERE p.id = recurrences.project_id), ''), due_in_seconds, next_at, created_at"
+
+var _ rep.RecurrenceRepository = (*repository)(nil)
+
+type repository struct {
+	pool *pgxpool.Pool
+	log  zerolog.Logger
+}
+
+func NewRepository(pool *pgxpool.Pool) *repository {
+	return &repository{
+		pool: pool,
+		log:  logger.GetLogger("repository.recurrence"),
+	}
+}
+
+func (r *repository) CreateRecurrence(ctx context.Context, recurrence *model.Recurrence) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+	recurrence.OwnerID = ownerID
+
+	// tags is NOT NULL, a nil slice would be sent as NULL.
+	tags := recurrence.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	query := `INSERT INTO recurrences (owner_id, rule, timezone, title, description, priority, tags, project_id, due_in_seconds, next_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, created_at`
+	err = r.pool.QueryRow(ctx, query, recurrence.OwnerID, recurrence.Rule, recurrence.Timezone, recurrence.Title, recurrence.Description,
+		recurrence.Priority, tags, recurrence.ProjectID, int64(recurrence.DueIn/time.Second), recurrence.NextAt).
+		Scan(&recurrence.ID, &recurrence.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create recurrence: %w", err)
+	}
+
+	r.log.Info().
+		Int("recurrence_id", recurrence.ID).
+		Str("rule", recurrence.Rule).
+		Time("next_at", recurrence.NextAt).
+		Msg("Recurrence created")
+	return nil
+}
+
+func (r *repository) List(ctx context.Context) ([]*model.Recurrence, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + recurrenceColumns + " FROM recurrences WHERE owner_id = $1 ORDER BY next_at, id"
+	rows, err := r.pool.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRecurrences(rows)
+}
+
+func (r *repository) DeleteRecurrence(ctx context.Context, id int) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.pool.Exec(ctx, "DELETE FROM recurrences WHERE id = $1 AND owner_id = $2", id, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurrence: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("recurrence with id %d %w", id, model.ErrNotFound)
+	}
+
+	r.log.Info().
+		Int("recurrence_id", id).
+		Msg("Recurrence deleted")
+	return nil
+}
+
+func (r *repository) Due(ctx context.Context, now time.Time) ([]*model.Recurrence, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + recurrenceColumns + " FROM recurrences WHERE ($1::int IS NULL OR owner_id = $1)" +
+		" AND (next_at <= $2 OR NOT EXISTS (SELECT 1 FROM tasks t WHERE t.recurrence_id = recurrences.id AND t.deleted_at IS NULL AND t.status NOT IN ($3, $4)))" +
+		" AND (project_id IS NULL OR project_id IN (SELECT id FROM projects WHERE archived_at IS NULL))" +
+		" ORDER BY next_at, id"
+	rows, err := r.pool.Query(ctx, query, ownerID, now, model.Closed, model.Cancelled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRecurrences(rows)
+}
+
+func (r *repository) Advance(ctx context.Context, id int, from, to time.Time) (bool, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	query := "UPDATE recurrences SET next_at = $1 WHERE id = $2 AND next_at = $3 AND ($4::int IS NULL OR owner_id = $4)"
+	result, err := r.pool.Exec(ctx, query, to, id, from, ownerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to advance recurrence: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func scanRecurrence(row pgx.Row) (*model.Recurrence, error) {
+	recurrence := &model.Recurrence{}
+	var dueIn int64
+	err := row.Scan(
+		&recurrence.ID,
+		&recurrence.OwnerID,
+		&recurrence.Rule,
+		&recurrence.Timezone,
+		&recurrence.Title,
+		&recurrence.Description,
+		&recurrence.Priority,
+		&recurrence.Tags,
+		&recurrence.ProjectID,
+		&recurrence.ProjectKey,
+		&dueIn,
+		&recurrence.NextAt,
+		&recurrence.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	recurrence.DueIn = time.Duration(dueIn) * time.Second
+	return recurrence, nil
+}
+
+func scanRecurrences(rows pgx.Rows) ([]*model.Recurrence, error) {
+	var recurrences []*model.Recurrence
+	for rows.Next() {
+		recurrence, err := scanRecurrence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed scan recurrence: %w", err)
+		}
+		recurrences = append(recurrences, recurrence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recurrences: %w", err)
+	}
+	return recurrences, nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"techno/internal/auth"
+	"techno/internal/model"
+)
+
+// OwnerScope returns the owner every query has to be restricted to. A nil
+// result means the caller is the system principal and sees every owner.
+func OwnerScope(ctx context.Context) (*int, error) {
+	p, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, model.ErrUnauthenticated
+	}
+	if p.IsSystem() {
+		return nil, nil
+	}
+
+	ownerID := p.UserID
+	return &ownerID, nil
+}
+
+// UserScope is OwnerScope for data that only makes sense per user, such as
+// tags. The system principal is rejected.
+func UserScope(ctx context.Context) (int, error) {
+	p, ok := auth.FromContext(ctx)
+	if !ok {
+		return 0, model.ErrUnauthenticated
+	}
+	if p.IsSystem() {
+		return 0, errors.New("operation requires a user principal")
+	}
+	return p.UserID, nil
+}
@@ -0,0 +1,69 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+var _ rep.SessionRepository = (*repository)(nil)
+
+type repository struct {
+	pool *pgxpool.Pool
+	log  zerolog.Logger
+}
+
+func NewRepository(pool *pgxpool.Pool) *repository {
+	return &repository{
+		pool: pool,
+		log:  logger.GetLogger("repository.session"),
+	}
+}
+
+func (r *repository) CreateSession(ctx context.Context, session *model.Session) error {
+	query := "INSERT INTO sessions (token_hash, user_id, expires_at) VALUES ($1, $2, $3) RETURNING created_at"
+
+	err := r.pool.QueryRow(ctx, query, session.TokenHash, session.UserID, session.ExpiresAt).Scan(&session.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	r.log.Info().
+		Int("user_id", session.UserID).
+		Time("expires_at", session.ExpiresAt).
+		Msg("Session created")
+	return nil
+}
+
+func (r *repository) GetByTokenHash(ctx context.Context, tokenHash string) (*model.Session, error) {
+	query := "SELECT token_hash, user_id, created_at, expires_at FROM sessions WHERE token_hash = $1"
+
+	var session model.Session
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(&session.TokenHash, &session.UserID, &session.CreatedAt, &session.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("session %w", model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return &session, nil
+}
+
+func (r *repository) DeleteSession(ctx context.Context, tokenHash string) error {
+	result, err := r.pool.Exec(ctx, "DELETE FROM sessions WHERE token_hash = $1", tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	r.log.Info().
+		Int64("rows_affected", result.RowsAffected()).
+		Msg("Session deleted")
+	return nil
+}
@@ -0,0 +1,230 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const archiveColumns = "id, COALESCE(owner_id, 0), title, description, status, priority, due_at, created_at, updated_at, completed_at, " +
+	"parent_id, recurrence_id, occurrence_at, project_id, COALESCE(project_number, 0), " +
+	"COALESCE((SELECT key FROM projects p WHERE p.id = tasks_archive.project_id), ''), tags, archived_at"
+
+var _ rep.ArchiveRepository = (*archiveRepository)(nil)
+
+type archiveRepository struct {
+	db  *sql.DB
+	log zerolog.Logger
+}
+
+func NewArchiveRepository(db *sql.DB) *archiveRepository {
+	return &archiveRepository{
+		db:  db,
+		log: logger.GetLogger("repository.sqlite.archive"),
+	}
+}
+
+func (r *archiveRepository) Archive(ctx context.Context, id int) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Subtasks move to the parent of the archived task, read them before.
+	moved, err := queryTasks(ctx, tx, "SELECT "+taskColumns+" FROM tasks WHERE parent_id = ?1", id)
+	if err != nil {
+		return err
+	}
+
+	now := toDBTime(time.Now())
+	var parentID *int
+	var taskOwner int
+	query := `INSERT INTO tasks_archive (id, owner_id, title, description, status, priority, due_at, created_at, updated_at, completed_at,
+			parent_id, project_id, project_number, recurrence_id, occurrence_at, tags, archived_at)
+		SELECT id, owner_id, title, description, status, priority, due_at, created_at, updated_at, completed_at,
+			parent_id, project_id, project_number, recurrence_id, occurrence_at, ` + tagsColumn + `, ?3
+		FROM tasks WHERE id = ?1 AND (?2 IS NULL OR owner_id = ?2) AND deleted_at IS NULL
+		RETURNING parent_id, COALESCE(owner_id, 0)`
+	if err := tx.QueryRowContext(ctx, query, id, ownerID, now).Scan(&parentID, &taskOwner); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("task with id %d %w", id, model.ErrNotFound)
+		}
+		return fmt.Errorf("failed to archive task: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE tasks SET parent_id = ?1, updated_at = ?3 WHERE parent_id = ?2", parentID, id, now); err != nil {
+		return fmt.Errorf("failed to move subtasks: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM tasks WHERE id = ?1", id); err != nil {
+		return fmt.Errorf("failed to delete archived task: %w", err)
+	}
+
+	events := []*model.TaskEvent{{TaskID: id, OwnerID: taskOwner, ActorID: ownerID, Kind: model.TaskArchived}}
+	for _, child := range moved {
+		after := *child
+		after.ParentID = parentID
+		events = append(events, model.NewTaskEvent(model.TaskUpdated, ownerID, child, &after))
+	}
+	if err := recordEvents(ctx, tx, events...); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("task_id", id).
+		Msg("Task archived")
+	return nil
+}
+
+func (r *archiveRepository) List(ctx context.Context, query string) ([]*model.ArchivedTask, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// LIKE ignores case for ASCII letters only, lower() is the same.
+	sqlQuery := "SELECT " + archiveColumns + " FROM tasks_archive WHERE (?1 IS NULL OR owner_id = ?1)" +
+		` AND (?2 = '' OR title LIKE '%' || ?2 || '%' ESCAPE '\' OR description LIKE '%' || ?2 || '%' ESCAPE '\')` +
+		" ORDER BY archived_at DESC, id DESC"
+	rows, err := r.db.QueryContext(ctx, sqlQuery, ownerID, rep.EscapeLike(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*model.ArchivedTask
+	for rows.Next() {
+		task, err := scanArchivedTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed scan archived task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+func (r *archiveRepository) Restore(ctx context.Context, id int) (*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := "SELECT " + archiveColumns + " FROM tasks_archive WHERE id = ?1 AND (?2 IS NULL OR owner_id = ?2)"
+	archived, err := scanArchivedTask(tx.QueryRowContext(ctx, query, id, ownerID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("archived task with id %d %w", id, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get archived task: %w", err)
+	}
+	task := &archived.Task
+	before := *task
+
+	if task.ParentID, err = existingID(ctx, tx, "tasks", task.ParentID); err != nil {
+		return nil, err
+	}
+	if task.RecurrenceID, err = existingID(ctx, tx, "recurrences", task.RecurrenceID); err != nil {
+		return nil, err
+	}
+	var number *int
+	if task.ProjectID != nil {
+		number = &task.Number
+	}
+
+	task.UpdatedAt = time.Now()
+	query = `INSERT INTO tasks (id, owner_id, title, description, status, priority, due_at, created_at, updated_at, completed_at,
+			parent_id, project_id, project_number, recurrence_id, occurrence_at)
+		VALUES (?1, NULLIF(?2, 0), ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15)`
+	_, err = tx.ExecContext(ctx, query, task.ID, task.OwnerID, task.Title, task.Description, task.Status, task.Priority, toDBNullTime(task.DueAt),
+		toDBTime(task.CreatedAt), toDBTime(task.UpdatedAt), toDBNullTime(task.CompletedAt), task.ParentID, task.ProjectID, number,
+		task.RecurrenceID, toDBNullTime(task.OccurrenceAt))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("task %s %w", task.DisplayID(), model.ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+	if err := replaceTags(ctx, tx, task); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM tasks_archive WHERE id = ?1", id); err != nil {
+		return nil, fmt.Errorf("failed to delete archived task: %w", err)
+	}
+	if err := recordEvents(ctx, tx, model.NewTaskEvent(model.TaskRestored, ownerID, &before, task)); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("task_id", id).
+		Msg("Task restored from archive")
+	return task, nil
+}
+
+// existingID returns id if a row with it is still in table and nil
+// otherwise.
+func existingID(ctx context.Context, tx *sql.Tx, table string, id *int) (*int, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	var found bool
+	if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = ?1)", *id).Scan(&found); err != nil {
+		return nil, fmt.Errorf("failed to look up %s %d: %w", table, *id, err)
+	}
+	if !found {
+		return nil, nil
+	}
+	return id, nil
+}
+
+func scanArchivedTask(row rowScanner) (*model.ArchivedTask, error) {
+	task := &model.ArchivedTask{}
+	err := row.Scan(
+		&task.ID,
+		&task.OwnerID,
+		&task.Title,
+		&task.Description,
+		&task.Status,
+		&task.Priority,
+		scanNullTime(&task.DueAt),
+		scanTime(&task.CreatedAt),
+		scanTime(&task.UpdatedAt),
+		scanNullTime(&task.CompletedAt),
+		&task.ParentID,
+		&task.RecurrenceID,
+		scanNullTime(&task.OccurrenceAt),
+		&task.ProjectID,
+		&task.Number,
+		&task.ProjectKey,
+		scanList(&task.Tags),
+		scanTime(&task.ArchivedAt),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.DependencyRepository = (*dependencyRepository)(nil)
+
+type dependencyRepository struct {
+	db  *sql.DB
+	log zerolog.Logger
+}
+
+func NewDependencyRepository(db *sql.DB) *dependencyRepository {
+	return &dependencyRepository{
+		db:  db,
+		log: logger.GetLogger("repository.sqlite.dependency"),
+	}
+}
+
+// Add only links tasks of the same owner, visible to the caller. The edge
+// is inserted before the graph is checked: the insert takes the database
+// write lock, so no other add can change the graph until the commit.
+func (r *dependencyRepository) Add(ctx context.Context, taskID, dependsOnID int) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO task_dependencies (task_id, depends_on_id, created_at)
+		SELECT t.id, d.id, ?4 FROM tasks t JOIN tasks d ON d.owner_id = t.owner_id
+		WHERE t.id = ?1 AND d.id = ?2 AND (?3 IS NULL OR t.owner_id = ?3)`
+	result, err := tx.ExecContext(ctx, query, taskID, dependsOnID, ownerID, toDBTime(time.Now()))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("dependency of task %d on %d %w", taskID, dependsOnID, model.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("task with id %d or %d %w", taskID, dependsOnID, model.ErrNotFound)
+	}
+
+	query = `SELECT d.task_id, d.depends_on_id FROM task_dependencies d JOIN tasks t ON t.id = d.task_id
+		WHERE t.owner_id = (SELECT owner_id FROM tasks WHERE id = ?1)`
+	rows, err := tx.QueryContext(ctx, query, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	graph, err := scanGraph(rows)
+	if err != nil {
+		return err
+	}
+	// The graph already has the new edge, it closes a cycle when the other
+	// task waits for this one.
+	if path := graph.Path(dependsOnID, taskID); path != nil {
+		return &model.CycleError{Path: append([]int{taskID}, path...)}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("task_id", taskID).
+		Int("depends_on_id", dependsOnID).
+		Msg("Dependency added")
+	return nil
+}
+
+func (r *dependencyRepository) Remove(ctx context.Context, taskID, dependsOnID int) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM task_dependencies WHERE task_id = ?1 AND depends_on_id = ?2
+		AND task_id IN (SELECT id FROM tasks WHERE ?3 IS NULL OR owner_id = ?3)`
+	result, err := r.db.ExecContext(ctx, query, taskID, dependsOnID, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("dependency of task %d on %d %w", taskID, dependsOnID, model.ErrNotFound)
+	}
+
+	r.log.Info().
+		Int("task_id", taskID).
+		Int("depends_on_id", dependsOnID).
+		Msg("Dependency removed")
+	return nil
+}
+
+func (r *dependencyRepository) Graph(ctx context.Context) (model.DependencyGraph, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT d.task_id, d.depends_on_id FROM task_dependencies d JOIN tasks t ON t.id = d.task_id
+		WHERE ?1 IS NULL OR t.owner_id = ?1`
+	rows, err := r.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	return scanGraph(rows)
+}
+
+func scanGraph(rows *sql.Rows) (model.DependencyGraph, error) {
+	defer rows.Close()
+
+	graph := make(model.DependencyGraph)
+	for rows.Next() {
+		var taskID, dependsOnID int
+		if err := rows.Scan(&taskID, &dependsOnID); err != nil {
+			return nil, fmt.Errorf("failed scan dependency: %w", err)
+		}
+		graph[taskID] = append(graph[taskID], dependsOnID)
+	}
+	return graph, rows.Err()
+}
@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const eventColumns = "id, task_id, COALESCE(owner_id, 0), actor_id, " +
+	"COALESCE((SELECT login FROM users u WHERE u.id = task_events.actor_id), 'system'), kind, changes, created_at"
+
+var _ rep.EventRepository = (*eventRepository)(nil)
+
+type eventRepository struct {
+	db  *sql.DB
+	log zerolog.Logger
+}
+
+func NewEventRepository(db *sql.DB) *eventRepository {
+	return &eventRepository{
+		db:  db,
+		log: logger.GetLogger("repository.sqlite.event"),
+	}
+}
+
+func (r *eventRepository) History(ctx context.Context, taskID int) ([]*model.TaskEvent, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + eventColumns + " FROM task_events WHERE task_id = ?1 AND (?2 IS NULL OR owner_id = ?2) ORDER BY created_at, id"
+	events, err := r.queryEvents(ctx, query, taskID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("history of task %d %w", taskID, model.ErrNotFound)
+	}
+	return events, nil
+}
+
+func (r *eventRepository) Until(ctx context.Context, at time.Time) ([]*model.TaskEvent, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + eventColumns + " FROM task_events WHERE created_at <= ?1 AND (?2 IS NULL OR owner_id = ?2) ORDER BY created_at, id"
+	return r.queryEvents(ctx, query, toDBTime(at), ownerID)
+}
+
+func (r *eventRepository) queryEvents(ctx context.Context, query string, args ...any) ([]*model.TaskEvent, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*model.TaskEvent
+	for rows.Next() {
+		event := &model.TaskEvent{}
+		var kind, changes string
+		if err := rows.Scan(&event.ID, &event.TaskID, &event.OwnerID, &event.ActorID, &event.Actor, &kind, &changes, scanTime(&event.CreatedAt)); err != nil {
+			return nil, fmt.Errorf("failed scan task event: %w", err)
+		}
+		event.Kind = model.TaskEventKind(kind)
+		if event.Changes, err = rep.DecodeChanges([]byte(changes)); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// recordEvents writes the events of a change in its transaction. Updates
+// that change no tracked field are not recorded.
+func recordEvents(ctx context.Context, tx *sql.Tx, events ...*model.TaskEvent) error {
+	now := toDBTime(time.Now())
+	query := "INSERT INTO task_events (task_id, owner_id, actor_id, kind, changes, created_at) VALUES (?1, NULLIF(?2, 0), ?3, ?4, ?5, ?6)"
+	for _, event := range events {
+		if event.Kind == model.TaskUpdated && len(event.Changes) == 0 {
+			continue
+		}
+		changes, err := rep.EncodeChanges(event.Changes)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query, event.TaskID, event.OwnerID, event.ActorID, string(event.Kind), changes, now); err != nil {
+			return fmt.Errorf("failed to record task event: %w", err)
+		}
+	}
+	return nil
+}
+
+// taskImage reads a task in a transaction, whether it is in the trash or
+// not.
+func taskImage(ctx context.Context, tx *sql.Tx, id int) (*model.Task, error) {
+	task, err := scanTask(tx.QueryRowContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id = ?1", id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task %d: %w", id, err)
+	}
+	return task, nil
+}
+
+// taskIDs returns the ids of tasks, in their order.
+func taskIDs(tasks []*model.Task) []int {
+	ids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
@@ -0,0 +1,171 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const operationColumns = "id, user_id, kind, task_id, before_image, after_image, child_policy, subtask_images, undone_at, created_at"
+
+var _ rep.JournalRepository = (*journalRepository)(nil)
+
+type journalRepository struct {
+	db  *sql.DB
+	log zerolog.Logger
+}
+
+func NewJournalRepository(db *sql.DB) *journalRepository {
+	return &journalRepository{
+		db:  db,
+		log: logger.GetLogger("repository.sqlite.journal"),
+	}
+}
+
+func (r *journalRepository) Done(ctx context.Context, limit int) ([]*model.Operation, error) {
+	userID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + operationColumns + " FROM operations WHERE user_id = ?1 AND undone_at IS NULL ORDER BY id DESC LIMIT ?2"
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list operations: %w", err)
+	}
+	defer rows.Close()
+
+	var ops []*model.Operation
+	for rows.Next() {
+		op, err := scanOperation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed scan operation: %w", err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, rows.Err()
+}
+
+func (r *journalRepository) LastUndone(ctx context.Context) (*model.Operation, error) {
+	userID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + operationColumns + " FROM operations WHERE user_id = ?1 AND undone_at IS NOT NULL ORDER BY undone_at DESC, id LIMIT 1"
+	op, err := scanOperation(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("undone operation %w", model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get operation: %w", err)
+	}
+	return op, nil
+}
+
+// journal writes the operation of a change in the transaction that makes
+// it, so the user can undo it. It forgets the undone operations, they
+// cannot be redone after a new change. Undo and redo mark the operation
+// they replay instead.
+func journal(ctx context.Context, tx *sql.Tx, op *model.Operation) error {
+	if !rep.Journaled(ctx) {
+		return markReplayed(ctx, tx)
+	}
+	userID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	beforeImage, err := rep.EncodeTaskImage(op.Before)
+	if err != nil {
+		return err
+	}
+	afterImage, err := rep.EncodeTaskImage(op.After)
+	if err != nil {
+		return err
+	}
+	subtaskImages, err := rep.EncodeSubtaskImages(op.Subtasks)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM operations WHERE user_id = ?1 AND undone_at IS NOT NULL", userID); err != nil {
+		return fmt.Errorf("failed to forget undone operations: %w", err)
+	}
+
+	query := "INSERT INTO operations (user_id, kind, task_id, before_image, after_image, child_policy, subtask_images, created_at) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)"
+	_, err = tx.ExecContext(ctx, query, userID, string(op.Kind), op.Task().ID, jsonText(beforeImage), jsonText(afterImage), int(op.Policy), subtaskImages, toDBTime(time.Now()))
+	if err != nil {
+		return fmt.Errorf("failed to record operation: %w", err)
+	}
+	return nil
+}
+
+// markReplayed marks the operation an undo or a redo replays, if the
+// change is one. The operation must still be in the state the replay
+// expects, so two concurrent undos cannot both apply it.
+func markReplayed(ctx context.Context, tx *sql.Tx) error {
+	replay, ok := rep.ReplayFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	userID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	var undoneAt *string
+	if replay.Undo {
+		now := toDBTime(time.Now())
+		undoneAt = &now
+	}
+	query := "UPDATE operations SET undone_at = ?3 WHERE id = ?1 AND user_id = ?2 AND (undone_at IS NULL) = ?4"
+	result, err := tx.ExecContext(ctx, query, replay.OperationID, userID, undoneAt, replay.Undo)
+	if err != nil {
+		return fmt.Errorf("failed to update operation: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return rep.ReplayConflict(replay)
+	}
+	return nil
+}
+
+// jsonText stores nil as NULL instead of an empty string.
+func jsonText(data []byte) *string {
+	if data == nil {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
+
+func scanOperation(row rowScanner) (*model.Operation, error) {
+	op := &model.Operation{}
+	var before, after *string
+	var subtasks string
+	err := row.Scan(&op.ID, &op.UserID, &op.Kind, &op.TaskID, &before, &after, &op.Policy, &subtasks, scanNullTime(&op.UndoneAt), scanTime(&op.CreatedAt))
+	if err != nil {
+		return nil, err
+	}
+	if before != nil {
+		if op.Before, err = rep.DecodeTaskImage([]byte(*before)); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		if op.After, err = rep.DecodeTaskImage([]byte(*after)); err != nil {
+			return nil, err
+		}
+	}
+	if op.Subtasks, err = rep.DecodeSubtaskImages([]byte(subtasks)); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
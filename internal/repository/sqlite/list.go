@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// toDBList passes a list of names as one JSON array parameter, queries
+// expand it with json_each.
+func toDBList(names []string) string {
+	encoded, _ := json.Marshal(names)
+	return string(encoded)
+}
+
+// toDBIDs passes a list of ids the same way.
+func toDBIDs(ids []int) string {
+	encoded, _ := json.Marshal(ids)
+	return string(encoded)
+}
+
+type listScanner struct {
+	dst *[]string
+}
+
+// scanList reads a comma separated group_concat column into dst. NULL
+// becomes an empty list.
+func scanList(dst *[]string) sql.Scanner {
+	return listScanner{dst: dst}
+}
+
+func (s listScanner) Scan(src any) error {
+	var raw string
+	switch v := src.(type) {
+	case nil:
+		*s.dst = []string{}
+		return nil
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("unsupported list type %T", src)
+	}
+
+	*s.dst = strings.Split(raw, ",")
+	return nil
+}
@@ -0,0 +1,141 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const projectColumns = "id, owner_id, key, name, description, archived_at, created_at, (SELECT COUNT(*) FROM tasks t WHERE t.project_id = projects.id AND t.deleted_at IS NULL)"
+
+var _ rep.ProjectRepository = (*projectRepository)(nil)
+
+type projectRepository struct {
+	db  *sql.DB
+	log zerolog.Logger
+}
+
+func NewProjectRepository(db *sql.DB) *projectRepository {
+	return &projectRepository{
+		db:  db,
+		log: logger.GetLogger("repository.sqlite.project"),
+	}
+}
+
+func (r *projectRepository) CreateProject(ctx context.Context, project *model.Project) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+	project.OwnerID = ownerID
+	project.CreatedAt = time.Now()
+
+	query := "INSERT INTO projects (owner_id, key, name, description, created_at) VALUES (?1, ?2, ?3, ?4, ?5) RETURNING id"
+	err = r.db.QueryRowContext(ctx, query, project.OwnerID, project.Key, project.Name, project.Description, toDBTime(project.CreatedAt)).Scan(&project.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("project %q %w", project.Key, model.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	r.log.Info().
+		Int("project_id", project.ID).
+		Str("key", project.Key).
+		Msg("Project created")
+	return nil
+}
+
+func (r *projectRepository) GetByKey(ctx context.Context, key string) (*model.Project, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + projectColumns + " FROM projects WHERE owner_id = ?1 AND key = ?2"
+
+	project, err := scanProject(r.db.QueryRowContext(ctx, query, ownerID, key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("project %q %w", key, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
+}
+
+func (r *projectRepository) List(ctx context.Context, includeArchived bool) ([]*model.Project, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + projectColumns + " FROM projects WHERE owner_id = ?1 AND (?2 OR archived_at IS NULL) ORDER BY key"
+	rows, err := r.db.QueryContext(ctx, query, ownerID, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []*model.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
+
+func (r *projectRepository) SetArchived(ctx context.Context, key string, archived bool) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	var archivedAt *time.Time
+	if archived {
+		now := time.Now()
+		archivedAt = &now
+	}
+
+	result, err := r.db.ExecContext(ctx, "UPDATE projects SET archived_at = ?1 WHERE owner_id = ?2 AND key = ?3", toDBNullTime(archivedAt), ownerID, key)
+	if err != nil {
+		return fmt.Errorf("failed to archive project: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("project %q %w", key, model.ErrNotFound)
+	}
+
+	r.log.Info().
+		Str("key", key).
+		Bool("archived", archived).
+		Msg("Project archive state changed")
+	return nil
+}
+
+func scanProject(row rowScanner) (*model.Project, error) {
+	project := &model.Project{}
+	err := row.Scan(
+		&project.ID,
+		&project.OwnerID,
+		&project.Key,
+		&project.Name,
+		&project.Description,
+		scanNullTime(&project.ArchivedAt),
+		scanTime(&project.CreatedAt),
+		&project.Tasks,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
@@ -0,0 +1,173 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const recurrenceColumns = "id, owner_id, rule, timezone, title, description, priority, tags, project_id, " +
+	"COALESCE((SELECT key FROM projects p WHERE p.id = recurrences.project_id), ''), due_in_seconds, next_at, created_at"
+
+var _ rep.RecurrenceRepository = (*recurrenceRepository)(nil)
+
+type recurrenceRepository struct {
+	db  *sql.DB
+	log zerolog.Logger
+}
+
+func NewRecurrenceRepository(db *sql.DB) *recurrenceRepository {
+	return &recurrenceRepository{
+		db:  db,
+		log: logger.GetLogger("repository.sqlite.recurrence"),
+	}
+}
+
+func (r *recurrenceRepository) CreateRecurrence(ctx context.Context, recurrence *model.Recurrence) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+	recurrence.OwnerID = ownerID
+	recurrence.CreatedAt = time.Now()
+
+	var tags any
+	if len(recurrence.Tags) > 0 {
+		tags = strings.Join(recurrence.Tags, ",")
+	}
+
+	query := `INSERT INTO recurrences (owner_id, rule, timezone, title, description, priority, tags, project_id, due_in_seconds, next_at, created_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11) RETURNING id`
+	err = r.db.QueryRowContext(ctx, query, recurrence.OwnerID, recurrence.Rule, recurrence.Timezone, recurrence.Title, recurrence.Description,
+		recurrence.Priority, tags, recurrence.ProjectID, int64(recurrence.DueIn/time.Second), toDBTime(recurrence.NextAt), toDBTime(recurrence.CreatedAt)).
+		Scan(&recurrence.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create recurrence: %w", err)
+	}
+
+	r.log.Info().
+		Int("recurrence_id", recurrence.ID).
+		Str("rule", recurrence.Rule).
+		Time("next_at", recurrence.NextAt).
+		Msg("Recurrence created")
+	return nil
+}
+
+func (r *recurrenceRepository) List(ctx context.Context) ([]*model.Recurrence, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + recurrenceColumns + " FROM recurrences WHERE owner_id = ?1 ORDER BY next_at, id"
+	return r.queryRecurrences(ctx, query, ownerID)
+}
+
+func (r *recurrenceRepository) DeleteRecurrence(ctx context.Context, id int) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM recurrences WHERE id = ?1 AND owner_id = ?2", id, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurrence: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to delete recurrence: %w", err)
+	} else if affected == 0 {
+		return fmt.Errorf("recurrence with id %d %w", id, model.ErrNotFound)
+	}
+
+	// tasks.recurrence_id has no foreign key to do this.
+	if _, err := tx.ExecContext(ctx, "UPDATE tasks SET recurrence_id = NULL WHERE recurrence_id = ?1", id); err != nil {
+		return fmt.Errorf("failed to unlink recurrence tasks: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("recurrence_id", id).
+		Msg("Recurrence deleted")
+	return nil
+}
+
+func (r *recurrenceRepository) Due(ctx context.Context, now time.Time) ([]*model.Recurrence, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + recurrenceColumns + " FROM recurrences WHERE (?1 IS NULL OR owner_id = ?1)" +
+		" AND (next_at <= ?2 OR NOT EXISTS (SELECT 1 FROM tasks t WHERE t.recurrence_id = recurrences.id AND t.deleted_at IS NULL AND t.status NOT IN (?3, ?4)))" +
+		" AND (project_id IS NULL OR project_id IN (SELECT id FROM projects WHERE archived_at IS NULL))" +
+		" ORDER BY next_at, id"
+	return r.queryRecurrences(ctx, query, ownerID, toDBTime(now), model.Closed, model.Cancelled)
+}
+
+func (r *recurrenceRepository) Advance(ctx context.Context, id int, from, to time.Time) (bool, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	query := "UPDATE recurrences SET next_at = ?1 WHERE id = ?2 AND next_at = ?3 AND (?4 IS NULL OR owner_id = ?4)"
+	result, err := r.db.ExecContext(ctx, query, toDBTime(to), id, toDBTime(from), ownerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to advance recurrence: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to advance recurrence: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *recurrenceRepository) queryRecurrences(ctx context.Context, query string, args ...any) ([]*model.Recurrence, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	var recurrences []*model.Recurrence
+	for rows.Next() {
+		recurrence := &model.Recurrence{}
+		var dueIn int64
+		err := rows.Scan(
+			&recurrence.ID,
+			&recurrence.OwnerID,
+			&recurrence.Rule,
+			&recurrence.Timezone,
+			&recurrence.Title,
+			&recurrence.Description,
+			&recurrence.Priority,
+			scanList(&recurrence.Tags),
+			&recurrence.ProjectID,
+			&recurrence.ProjectKey,
+			&dueIn,
+			scanTime(&recurrence.NextAt),
+			scanTime(&recurrence.CreatedAt),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed scan recurrence: %w", err)
+		}
+		recurrence.DueIn = time.Duration(dueIn) * time.Second
+		recurrences = append(recurrences, recurrence)
+	}
+	return recurrences, rows.Err()
+}
@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+)
+
+var _ rep.SessionRepository = (*sessionRepository)(nil)
+
+type sessionRepository struct {
+	db *sql.DB
+}
+
+func NewSessionRepository(db *sql.DB) *sessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+func (r *sessionRepository) CreateSession(ctx context.Context, session *model.Session) error {
+	session.CreatedAt = time.Now()
+
+	query := "INSERT INTO sessions (token_hash, user_id, created_at, expires_at) VALUES (?1, ?2, ?3, ?4)"
+	_, err := r.db.ExecContext(ctx, query, session.TokenHash, session.UserID, toDBTime(session.CreatedAt), toDBTime(session.ExpiresAt))
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*model.Session, error) {
+	query := "SELECT token_hash, user_id, created_at, expires_at FROM sessions WHERE token_hash = ?1"
+
+	var session model.Session
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&session.TokenHash, &session.UserID, scanTime(&session.CreatedAt), scanTime(&session.ExpiresAt))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("session %w", model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM sessions WHERE token_hash = ?1", tokenHash); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.TagRepository = (*tagRepository)(nil)
+
+type tagRepository struct {
+	db  *sql.DB
+	log zerolog.Logger
+}
+
+func NewTagRepository(db *sql.DB) *tagRepository {
+	return &tagRepository{
+		db:  db,
+		log: logger.GetLogger("repository.sqlite.tag"),
+	}
+}
+
+func (r *tagRepository) List(ctx context.Context) ([]*model.Tag, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT tg.id, tg.owner_id, tg.name, COUNT(tt.task_id)
+		FROM tags tg LEFT JOIN task_tags tt ON tt.tag_id = tg.id
+			AND tt.task_id IN (SELECT id FROM tasks WHERE deleted_at IS NULL)
+		WHERE tg.owner_id = ?1
+		GROUP BY tg.id
+		ORDER BY tg.name`
+	rows, err := r.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*model.Tag
+	for rows.Next() {
+		tag := &model.Tag{}
+		if err := rows.Scan(&tag.ID, &tag.OwnerID, &tag.Name, &tag.Tasks); err != nil {
+			return nil, fmt.Errorf("failed scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// Rename updates the tags row, every task sees the new name at once because
+// task_tags references the tag by id. Recurrences and archived tasks keep
+// tag names and are updated in the same transaction.
+func (r *tagRepository) Rename(ctx context.Context, from, to string) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tagged, err := taggedTasks(ctx, tx, ownerID, []string{from})
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "UPDATE tags SET name = ?1 WHERE owner_id = ?2 AND name = ?3", to, ownerID, from)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("tag %q %w, merge the tags instead", to, model.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to rename tag: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("tag %q %w", from, model.ErrNotFound)
+	}
+
+	if err := retagNames(ctx, tx, ownerID, []string{from}, to); err != nil {
+		return err
+	}
+	if err := recordRetag(ctx, tx, ownerID, tagged); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("owner_id", ownerID).
+		Str("from", from).
+		Str("to", to).
+		Msg("Tag renamed")
+	return nil
+}
+
+// Merge moves every task tagged with one of sources to target and removes
+// the source tags, in one transaction.
+func (r *tagRepository) Merge(ctx context.Context, sources []string, target string) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var found int
+	query := "SELECT COUNT(*) FROM tags WHERE owner_id = ?1 AND name IN (SELECT value FROM json_each(?2))"
+	if err := tx.QueryRowContext(ctx, query, ownerID, toDBList(sources)).Scan(&found); err != nil {
+		return fmt.Errorf("failed to find tags: %w", err)
+	}
+	if found != len(sources) {
+		return fmt.Errorf("tag %w, check the names with `tag list`", model.ErrNotFound)
+	}
+	tagged, err := taggedTasks(ctx, tx, ownerID, sources)
+	if err != nil {
+		return err
+	}
+
+	query = "INSERT INTO tags (owner_id, name, created_at) VALUES (?1, ?2, ?3) ON CONFLICT (owner_id, name) DO NOTHING"
+	if _, err := tx.ExecContext(ctx, query, ownerID, target, toDBTime(time.Now())); err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	query = `INSERT INTO task_tags (task_id, tag_id)
+		SELECT tt.task_id, t.id FROM task_tags tt
+		JOIN tags s ON s.id = tt.tag_id
+		JOIN tags t ON t.owner_id = s.owner_id AND t.name = ?3
+		WHERE s.owner_id = ?1 AND s.name IN (SELECT value FROM json_each(?2))
+		ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, ownerID, toDBList(sources), target); err != nil {
+		return fmt.Errorf("failed to retag tasks: %w", err)
+	}
+
+	query = "DELETE FROM tags WHERE owner_id = ?1 AND name IN (SELECT value FROM json_each(?2)) AND name <> ?3"
+	if _, err := tx.ExecContext(ctx, query, ownerID, toDBList(sources), target); err != nil {
+		return fmt.Errorf("failed to delete merged tags: %w", err)
+	}
+
+	if err := retagNames(ctx, tx, ownerID, sources, target); err != nil {
+		return err
+	}
+	if err := recordRetag(ctx, tx, ownerID, tagged); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("owner_id", ownerID).
+		Strs("sources", sources).
+		Str("target", target).
+		Msg("Tags merged")
+	return nil
+}
+
+// Delete removes the tag from every task, archived task and recurrence, the
+// tasks themselves stay.
+func (r *tagRepository) Delete(ctx context.Context, name string) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tagged, err := taggedTasks(ctx, tx, ownerID, []string{name})
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM tags WHERE owner_id = ?1 AND name = ?2", ownerID, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("tag %q %w", name, model.ErrNotFound)
+	}
+
+	if err := retagNames(ctx, tx, ownerID, []string{name}, ""); err != nil {
+		return err
+	}
+	if err := recordRetag(ctx, tx, ownerID, tagged); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("owner_id", ownerID).
+		Str("name", name).
+		Msg("Tag deleted")
+	return nil
+}
+
+// namedTagTables keep tag names instead of task_tags rows.
+var namedTagTables = []string{"recurrences", "tasks_archive"}
+
+// retagNames replaces sources with target in the tag names of the owner's
+// recurrences and archived tasks, an empty target only removes them.
+func retagNames(ctx context.Context, tx *sql.Tx, ownerID int, sources []string, target string) error {
+	for _, table := range namedTagTables {
+		rows, err := tx.QueryContext(ctx, "SELECT id, tags FROM "+table+" WHERE owner_id = ?1 AND tags IS NOT NULL", ownerID)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", table, err)
+		}
+
+		retagged := make(map[int][]string)
+		for rows.Next() {
+			var id int
+			var tags []string
+			if err := rows.Scan(&id, scanList(&tags)); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed scan %s: %w", table, err)
+			}
+			if tags, changed := model.Retag(tags, sources, target); changed {
+				retagged[id] = tags
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to load %s: %w", table, err)
+		}
+
+		for id, tags := range retagged {
+			var value any
+			if len(tags) > 0 {
+				value = strings.Join(tags, ",")
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE "+table+" SET tags = ?1 WHERE id = ?2", value, id); err != nil {
+				return fmt.Errorf("failed to retag %s: %w", table, err)
+			}
+		}
+	}
+	return nil
+}
+
+// taggedTasks reads the owner's tasks that carry one of names, trashed
+// tasks included.
+func taggedTasks(ctx context.Context, tx *sql.Tx, ownerID int, names []string) ([]*model.Task, error) {
+	query := "SELECT " + taskColumns + ` FROM tasks WHERE id IN (
+			SELECT tt.task_id FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+			WHERE tg.owner_id = ?1 AND tg.name IN (SELECT value FROM json_each(?2))
+		) ORDER BY id`
+	return queryTasks(ctx, tx, query, ownerID, toDBList(names))
+}
+
+// recordRetag records an update event for every task whose tags a tag
+// change touched, in the transaction of the change.
+func recordRetag(ctx context.Context, tx *sql.Tx, actorID int, tagged []*model.Task) error {
+	events := make([]*model.TaskEvent, 0, len(tagged))
+	for _, before := range tagged {
+		after, err := taskImage(ctx, tx, before.ID)
+		if err != nil {
+			return err
+		}
+		events = append(events, model.NewTaskEvent(model.TaskUpdated, &actorID, before, after))
+	}
+	return recordEvents(ctx, tx, events...)
+}
@@ -0,0 +1,609 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var taskColumns = "id, COALESCE(owner_id, 0), title, description, status, priority, due_at, created_at, updated_at, completed_at, deleted_at, parent_id, recurrence_id, occurrence_at, " +
+	"project_id, COALESCE(project_number, 0), COALESCE((SELECT key FROM projects p WHERE p.id = tasks.project_id), ''), " +
+	openDependenciesColumn + ", " + tagsColumn
+
+// openDependenciesColumn counts the unfinished tasks the selected task
+// depends on. Tasks in the trash do not hold anything up.
+var openDependenciesColumn = fmt.Sprintf("(SELECT COUNT(*) FROM task_dependencies d JOIN tasks dt ON dt.id = d.depends_on_id "+
+	"WHERE d.task_id = tasks.id AND dt.deleted_at IS NULL AND dt.status NOT IN (%d, %d))", model.Closed, model.Cancelled)
+
+// tagsColumn collects the tag names of the selected task row as a comma
+// separated list, tag names never contain commas.
+const tagsColumn = "(SELECT group_concat(name, ',') FROM (SELECT tg.name FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id ORDER BY tg.name))"
+
+var _ rep.TaskRepository = (*taskRepository)(nil)
+
+type taskRepository struct {
+	db  *sql.DB
+	log zerolog.Logger
+}
+
+func NewTaskRepository(db *sql.DB) *taskRepository {
+	return &taskRepository{
+		db:  db,
+		log: logger.GetLogger("repository.sqlite.task"),
+	}
+}
+
+func (r *taskRepository) CreateTask(ctx context.Context, task *model.Task) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+	if ownerID != nil {
+		task.OwnerID = *ownerID
+	}
+	if task.OwnerID <= 0 {
+		return errors.New("task owner is required")
+	}
+
+	task.Status = model.Open
+	task.CreatedAt = time.Now()
+	task.UpdatedAt = task.CreatedAt
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var number *int
+	if task.ProjectID != nil {
+		if err := assignNumber(ctx, tx, task, task.OwnerID); err != nil {
+			return err
+		}
+		number = &task.Number
+	}
+
+	query := "INSERT INTO tasks (owner_id, title, description, status, priority, due_at, created_at, updated_at, project_id, project_number, parent_id, recurrence_id, occurrence_at) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?7, ?8, ?9, ?10, ?11, ?12) RETURNING id"
+	err = tx.QueryRowContext(ctx, query, task.OwnerID, task.Title, task.Description, task.Status, task.Priority, toDBNullTime(task.DueAt), toDBTime(task.CreatedAt), task.ProjectID, number, task.ParentID, task.RecurrenceID, toDBNullTime(task.OccurrenceAt)).Scan(&task.ID)
+	if err != nil {
+		if isUniqueViolation(err) && task.RecurrenceID != nil {
+			return fmt.Errorf("occurrence of recurrence %d at %s %w", *task.RecurrenceID, task.OccurrenceAt.Format(time.RFC3339), model.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed created task: %w", err)
+	}
+	if err := replaceTags(ctx, tx, task); err != nil {
+		return err
+	}
+	// The owner scope is the user making the change, nil for the system.
+	created, err := taskImage(ctx, tx, task.ID)
+	if err != nil {
+		return err
+	}
+	if err := recordEvents(ctx, tx, model.NewTaskEvent(model.TaskCreated, ownerID, nil, created)); err != nil {
+		return err
+	}
+	if err := journal(ctx, tx, &model.Operation{Kind: model.OperationCreate, After: created}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("task_id", task.ID).
+		Str("title", task.Title).
+		Msg("Task created successfull")
+	return nil
+}
+
+func (r *taskRepository) GetByID(ctx context.Context, id int) (*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = ?1 AND (?2 IS NULL OR owner_id = ?2) AND deleted_at IS NULL"
+
+	task, err := scanTask(r.db.QueryRowContext(ctx, query, id, ownerID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("task with id %d %w", id, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	return task, nil
+}
+
+func (r *taskRepository) GetByProjectNumber(ctx context.Context, projectKey string, number int) (*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE project_number = ?2 AND (?3 IS NULL OR owner_id = ?3) AND deleted_at IS NULL" +
+		" AND project_id IN (SELECT id FROM projects WHERE key = ?1 AND owner_id = tasks.owner_id)"
+
+	task, err := scanTask(r.db.QueryRowContext(ctx, query, projectKey, number, ownerID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("task %s-%d %w", projectKey, number, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	return task, nil
+}
+
+func (r *taskRepository) GetSubtasks(ctx context.Context, id int) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE parent_id = ?1 AND (?2 IS NULL OR owner_id = ?2) AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		SELECT ` + taskColumns + ` FROM tasks WHERE id IN (SELECT id FROM subtree) ORDER BY created_at, id`
+	return queryTasks(ctx, r.db, query, id, ownerID)
+}
+
+func (r *taskRepository) GetAll(ctx context.Context) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE (?1 IS NULL OR owner_id = ?1) AND deleted_at IS NULL ORDER BY created_at DESC, id DESC"
+	return queryTasks(ctx, r.db, query, ownerID)
+}
+
+func (r *taskRepository) GetByStatus(ctx context.Context, status model.TaskStatus) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE status = ?1 AND (?2 IS NULL OR owner_id = ?2) AND deleted_at IS NULL ORDER BY created_at DESC, id DESC"
+	return queryTasks(ctx, r.db, query, status, ownerID)
+}
+
+func (r *taskRepository) List(ctx context.Context, filter model.TaskFilter) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []any{ownerID}
+	conditions := []string{"(?1 IS NULL OR owner_id = ?1)", "deleted_at IS NULL"}
+	addCondition := func(format string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.Status != nil {
+		addCondition("status = ?%d", *filter.Status)
+	}
+	if filter.Overdue {
+		addCondition("due_at < ?%d", toDBTime(time.Now()))
+		conditions = append(conditions, fmt.Sprintf("status NOT IN (%d, %d)", model.Closed, model.Cancelled))
+	}
+	if filter.DueBefore != nil {
+		addCondition("due_at < ?%d", toDBTime(*filter.DueBefore))
+	}
+	if filter.DueAfter != nil {
+		addCondition("due_at > ?%d", toDBTime(*filter.DueAfter))
+	}
+	if filter.Project != "" {
+		addCondition("project_id IN (SELECT id FROM projects WHERE key = ?%d AND owner_id = tasks.owner_id)", filter.Project)
+	} else {
+		conditions = append(conditions, "(project_id IS NULL OR project_id IN (SELECT id FROM projects WHERE archived_at IS NULL))")
+	}
+	if len(filter.Tags) > 0 {
+		tagged := "SELECT COUNT(*) FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id AND tg.name IN (SELECT value FROM json_each(?%d))"
+		if filter.AllTags {
+			addCondition("("+tagged+") = "+strconv.Itoa(len(filter.Tags)), toDBList(filter.Tags))
+		} else {
+			addCondition("("+tagged+") > 0", toDBList(filter.Tags))
+		}
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE " + strings.Join(conditions, " AND ") + " ORDER BY " + rep.OrderBy(filter.Sort)
+	return queryTasks(ctx, r.db, query, args...)
+}
+
+func (r *taskRepository) UpdateTask(ctx context.Context, task *model.Task, expected *model.Task) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var taskOwner int
+	var currentProject, currentNumber *int
+	query := "SELECT owner_id, project_id, project_number FROM tasks WHERE id = ?1 AND (?2 IS NULL OR owner_id = ?2) AND deleted_at IS NULL"
+	if err := tx.QueryRowContext(ctx, query, task.ID, ownerID).Scan(&taskOwner, &currentProject, &currentNumber); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("task with id %d %w", task.ID, model.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	before, err := taskImage(ctx, tx, task.ID)
+	if err != nil {
+		return err
+	}
+	if expected != nil && !model.SameState(before, expected) {
+		return fmt.Errorf("%w: task %d was changed since it was read", model.ErrConflict, task.ID)
+	}
+
+	// A task keeps its number while it stays in the same project and gets
+	// the next number of the project it moves to.
+	number := currentNumber
+	switch {
+	case task.ProjectID == nil:
+		number = nil
+		task.ProjectKey, task.Number = "", 0
+	case currentProject == nil || *currentProject != *task.ProjectID:
+		if err := assignNumber(ctx, tx, task, taskOwner); err != nil {
+			return err
+		}
+		number = &task.Number
+	}
+
+	// completed_at keeps the first finish time while a task moves between
+	// finished statuses and is cleared when it is reopened.
+	query = "UPDATE tasks SET title = ?1, description = ?2, status = ?3, priority = ?4, due_at = ?5, project_id = ?6, project_number = ?7, parent_id = ?8, " +
+		"updated_at = ?10, completed_at = " + completedAtExpr("?3", "?10") + " WHERE id = ?9 RETURNING updated_at, completed_at"
+	err = tx.QueryRowContext(ctx, query, task.Title, task.Description, task.Status, task.Priority, toDBNullTime(task.DueAt), task.ProjectID, number, task.ParentID, task.ID, toDBTime(time.Now())).
+		Scan(scanTime(&task.UpdatedAt), scanNullTime(&task.CompletedAt))
+	if err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	if err := replaceTags(ctx, tx, task); err != nil {
+		return err
+	}
+	after, err := taskImage(ctx, tx, task.ID)
+	if err != nil {
+		return err
+	}
+	if err := recordEvents(ctx, tx, model.NewTaskEvent(model.TaskUpdated, ownerID, before, after)); err != nil {
+		return err
+	}
+	if err := journal(ctx, tx, &model.Operation{Kind: model.OperationUpdate, Before: before, After: after}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("task_id", task.ID).
+		Str("status", task.Status.StringStatus()).
+		Msg("Task updated successfull")
+	return nil
+}
+
+func (r *taskRepository) DeleteTask(ctx context.Context, id int, policy model.ChildPolicy, expected *model.Task, subtasks []*model.Task) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var parentID *int
+	var children int
+	query := "SELECT parent_id, (SELECT COUNT(*) FROM tasks c WHERE c.parent_id = tasks.id AND c.deleted_at IS NULL) FROM tasks " +
+		"WHERE id = ?1 AND (?2 IS NULL OR owner_id = ?2) AND deleted_at IS NULL"
+	if err := tx.QueryRowContext(ctx, query, id, ownerID).Scan(&parentID, &children); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("task with id %d %w", id, model.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	before, err := taskImage(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if expected != nil && !model.SameState(before, expected) {
+		return fmt.Errorf("%w: task %d was changed since it was read", model.ErrConflict, id)
+	}
+
+	// The journal keeps the images of the subtasks the policy touches and
+	// a redo has to find the same ones.
+	var touched []*model.Task
+	switch {
+	case children == 0:
+	case policy == model.ChildrenCascade:
+		query = `WITH RECURSIVE subtree AS (
+				SELECT id FROM tasks WHERE parent_id = ?1 AND deleted_at IS NULL
+				UNION ALL
+				SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+			)
+			SELECT ` + taskColumns + ` FROM tasks WHERE id IN (SELECT id FROM subtree) ORDER BY id`
+		touched, err = queryTasks(ctx, tx, query, id)
+	case policy == model.ChildrenReparent:
+		touched, err = queryTasks(ctx, tx, "SELECT "+taskColumns+" FROM tasks WHERE parent_id = ?1 AND deleted_at IS NULL ORDER BY id", id)
+	default:
+		return fmt.Errorf("task %d has %d subtask(s): %w", id, children, model.ErrHasSubtasks)
+	}
+	if err != nil {
+		return err
+	}
+	if expected != nil && !model.SameTasks(touched, subtasks) {
+		return fmt.Errorf("%w: subtasks of task %d were changed since it was deleted", model.ErrConflict, id)
+	}
+
+	// Deleting moves tasks to the trash. A cascade shares one deleted_at.
+	now := toDBTime(time.Now())
+	op := &model.Operation{Kind: model.OperationDelete, Before: before, Policy: policy}
+	trashed := []int{id}
+	var events []*model.TaskEvent
+	if policy == model.ChildrenReparent && len(touched) > 0 {
+		query = "UPDATE tasks SET parent_id = ?1, updated_at = ?3 WHERE id IN (SELECT value FROM json_each(?2))"
+		if _, err := tx.ExecContext(ctx, query, parentID, toDBIDs(taskIDs(touched)), now); err != nil {
+			return fmt.Errorf("failed to move subtasks: %w", err)
+		}
+		for _, child := range touched {
+			after := *child
+			after.ParentID = parentID
+			op.Subtasks = append(op.Subtasks, model.SubtaskChange{Before: child, After: &after})
+			events = append(events, model.NewTaskEvent(model.TaskUpdated, ownerID, child, &after))
+		}
+	} else {
+		for _, sub := range touched {
+			op.Subtasks = append(op.Subtasks, model.SubtaskChange{Before: sub})
+		}
+		trashed = append(trashed, taskIDs(touched)...)
+	}
+
+	query = "UPDATE tasks SET deleted_at = ?2 WHERE id IN (SELECT value FROM json_each(?1)) RETURNING id, COALESCE(owner_id, 0)"
+	rows, err := tx.QueryContext(ctx, query, toDBIDs(trashed), now)
+	if err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	for rows.Next() {
+		event := &model.TaskEvent{Kind: model.TaskDeleted, ActorID: ownerID}
+		if err := rows.Scan(&event.TaskID, &event.OwnerID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to delete task: %w", err)
+		}
+		events = append(events, event)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	if err := recordEvents(ctx, tx, events...); err != nil {
+		return err
+	}
+	if err := journal(ctx, tx, op); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("task_id", id).
+		Str("children", policy.String()).
+		Msg("Task deleted successfully")
+	return nil
+}
+
+func (r *taskRepository) UndeleteTask(ctx context.Context, op *model.Operation) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var parentDeleted bool
+	query := "SELECT COALESCE((SELECT p.deleted_at IS NOT NULL FROM tasks p WHERE p.id = tasks.parent_id), 0) FROM tasks " +
+		"WHERE id = ?1 AND (?2 IS NULL OR owner_id = ?2) AND deleted_at IS NOT NULL"
+	if err := tx.QueryRowContext(ctx, query, op.TaskID, ownerID).Scan(&parentDeleted); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("deleted task with id %d %w", op.TaskID, model.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	if parentDeleted {
+		return fmt.Errorf("%w: the parent of task %d is in the trash", model.ErrConflict, op.TaskID)
+	}
+	deleted, err := taskImage(ctx, tx, op.TaskID)
+	if err != nil {
+		return err
+	}
+	if !model.SameState(deleted, op.Before) {
+		return fmt.Errorf("%w: task %d was changed since it was deleted", model.ErrConflict, op.TaskID)
+	}
+
+	// Every subtask must still be where the delete left it: in the trash,
+	// or live under the new parent.
+	restored := []int{op.TaskID}
+	var moved []*model.Task
+	for _, sub := range op.Subtasks {
+		current, err := scanTask(tx.QueryRowContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id = ?1", sub.Before.ID))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: subtask %d no longer exists", model.ErrConflict, sub.Before.ID)
+			}
+			return fmt.Errorf("failed to get subtask: %w", err)
+		}
+		if sub.After == nil {
+			if current.DeletedAt == nil || !model.SameState(current, sub.Before) {
+				return fmt.Errorf("%w: subtask %d was changed since it was deleted", model.ErrConflict, current.ID)
+			}
+			restored = append(restored, current.ID)
+			continue
+		}
+		if current.DeletedAt != nil || !model.SameState(current, sub.After) {
+			return fmt.Errorf("%w: subtask %d was changed since it was moved", model.ErrConflict, current.ID)
+		}
+		moved = append(moved, current)
+	}
+
+	now := toDBTime(time.Now())
+	query = "UPDATE tasks SET deleted_at = NULL, updated_at = ?2 WHERE id IN (SELECT value FROM json_each(?1))"
+	if _, err := tx.ExecContext(ctx, query, toDBIDs(restored), now); err != nil {
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+	query = "UPDATE tasks SET parent_id = ?1, updated_at = ?3 WHERE id IN (SELECT value FROM json_each(?2))"
+	if _, err := tx.ExecContext(ctx, query, op.TaskID, toDBIDs(taskIDs(moved)), now); err != nil {
+		return fmt.Errorf("failed to move subtasks back: %w", err)
+	}
+
+	query = "SELECT " + taskColumns + " FROM tasks WHERE id IN (SELECT value FROM json_each(?1)) ORDER BY id = ?2 DESC, created_at, id"
+	tasks, err := queryTasks(ctx, tx, query, toDBIDs(restored), op.TaskID)
+	if err != nil {
+		return err
+	}
+	events := rep.RestoreEvents(ownerID, deleted, tasks)
+	for _, child := range moved {
+		after := *child
+		after.ParentID = &op.TaskID
+		events = append(events, model.NewTaskEvent(model.TaskUpdated, ownerID, child, &after))
+	}
+	if err := recordEvents(ctx, tx, events...); err != nil {
+		return err
+	}
+	if err := markReplayed(ctx, tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("task_id", op.TaskID).
+		Int("restored", len(restored)).
+		Int("moved_back", len(moved)).
+		Msg("Task delete undone")
+	return nil
+}
+
+// completedAtExpr computes completed_at for a row getting the new status.
+func completedAtExpr(status, now string) string {
+	return fmt.Sprintf("CASE WHEN %s IN (%d, %d) THEN COALESCE(completed_at, %s) ELSE NULL END", status, model.Closed, model.Cancelled, now)
+}
+
+// assignNumber takes the next number of task.ProjectID, which has to belong
+// to ownerID, and fills task.Number and task.ProjectKey.
+func assignNumber(ctx context.Context, tx *sql.Tx, task *model.Task, ownerID int) error {
+	query := "UPDATE projects SET last_number = last_number + 1 WHERE id = ?1 AND owner_id = ?2 RETURNING key, last_number"
+	err := tx.QueryRowContext(ctx, query, *task.ProjectID, ownerID).Scan(&task.ProjectKey, &task.Number)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("project with id %d %w", *task.ProjectID, model.ErrNotFound)
+		}
+		return fmt.Errorf("failed to number task: %w", err)
+	}
+	return nil
+}
+
+// replaceTags makes task.Tags the exact tag set of the task, creating the
+// tags its owner does not have yet.
+func replaceTags(ctx context.Context, tx *sql.Tx, task *model.Task) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = ?1", task.ID); err != nil {
+		return fmt.Errorf("failed to clear task tags: %w", err)
+	}
+	if len(task.Tags) == 0 {
+		return nil
+	}
+
+	query := `INSERT INTO tags (owner_id, name, created_at)
+		SELECT t.owner_id, n.value, ?3 FROM tasks t, json_each(?2) n WHERE t.id = ?1
+		ON CONFLICT (owner_id, name) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, task.ID, toDBList(task.Tags), toDBTime(time.Now())); err != nil {
+		return fmt.Errorf("failed to create tags: %w", err)
+	}
+
+	query = `INSERT INTO task_tags (task_id, tag_id)
+		SELECT t.id, tg.id FROM tasks t JOIN tags tg ON tg.owner_id = t.owner_id
+		WHERE t.id = ?1 AND tg.name IN (SELECT value FROM json_each(?2))`
+	if _, err := tx.ExecContext(ctx, query, task.ID, toDBList(task.Tags)); err != nil {
+		return fmt.Errorf("failed to tag task: %w", err)
+	}
+	return nil
+}
+
+// queryer is what *sql.DB and *sql.Tx have in common for reading rows.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func queryTasks(ctx context.Context, q queryer, query string, args ...any) ([]*model.Task, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*model.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTask(row rowScanner) (*model.Task, error) {
+	task := &model.Task{}
+	err := row.Scan(
+		&task.ID,
+		&task.OwnerID,
+		&task.Title,
+		&task.Description,
+		&task.Status,
+		&task.Priority,
+		scanNullTime(&task.DueAt),
+		scanTime(&task.CreatedAt),
+		scanTime(&task.UpdatedAt),
+		scanNullTime(&task.CompletedAt),
+		scanNullTime(&task.DeletedAt),
+		&task.ParentID,
+		&task.RecurrenceID,
+		scanNullTime(&task.OccurrenceAt),
+		&task.ProjectID,
+		&task.Number,
+		&task.ProjectKey,
+		&task.OpenDependencies,
+		scanList(&task.Tags),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
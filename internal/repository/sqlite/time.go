@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// timeLayout is fixed width so TEXT columns compare in time order.
+const timeLayout = "2006-01-02 15:04:05.000000"
+
+func toDBTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+type timeScanner struct {
+	dst *time.Time
+}
+
+// scanTime parses a TEXT timestamp column into dst.
+func scanTime(dst *time.Time) sql.Scanner {
+	return timeScanner{dst: dst}
+}
+
+func (s timeScanner) Scan(src any) error {
+	var raw string
+	switch v := src.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("unsupported timestamp type %T", src)
+	}
+
+	t, err := time.ParseInLocation(timeLayout, raw, time.UTC)
+	if err != nil {
+		return fmt.Errorf("failed to parse timestamp %q: %w", raw, err)
+	}
+	*s.dst = t.Local()
+	return nil
+}
+
+// toDBNullTime maps a nil time to NULL.
+func toDBNullTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return toDBTime(*t)
+}
+
+type nullTimeScanner struct {
+	dst **time.Time
+}
+
+// scanNullTime parses a nullable TEXT timestamp column into dst.
+func scanNullTime(dst **time.Time) sql.Scanner {
+	return nullTimeScanner{dst: dst}
+}
+
+func (s nullTimeScanner) Scan(src any) error {
+	if src == nil {
+		*s.dst = nil
+		return nil
+	}
+
+	var t time.Time
+	if err := scanTime(&t).Scan(src); err != nil {
+		return err
+	}
+	*s.dst = &t
+	return nil
+}
@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.TrashRepository = (*trashRepository)(nil)
+
+type trashRepository struct {
+	db  *sql.DB
+	log zerolog.Logger
+}
+
+func NewTrashRepository(db *sql.DB) *trashRepository {
+	return &trashRepository{
+		db:  db,
+		log: logger.GetLogger("repository.sqlite.trash"),
+	}
+}
+
+func (r *trashRepository) List(ctx context.Context) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE deleted_at IS NOT NULL AND (?1 IS NULL OR owner_id = ?1) ORDER BY deleted_at DESC, id DESC"
+	return queryTasks(ctx, r.db, query, ownerID)
+}
+
+func (r *trashRepository) Restore(ctx context.Context, id int) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var parentDeleted bool
+	query := "SELECT COALESCE((SELECT p.deleted_at IS NOT NULL FROM tasks p WHERE p.id = tasks.parent_id), 0) FROM tasks " +
+		"WHERE id = ?1 AND (?2 IS NULL OR owner_id = ?2) AND deleted_at IS NOT NULL"
+	if err := tx.QueryRowContext(ctx, query, id, ownerID).Scan(&parentDeleted); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("deleted task with id %d %w", id, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	deleted, err := taskImage(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if parentDeleted {
+		if _, err := tx.ExecContext(ctx, "UPDATE tasks SET parent_id = NULL WHERE id = ?1", id); err != nil {
+			return nil, fmt.Errorf("failed to detach task from its parent: %w", err)
+		}
+	}
+
+	// Subtasks deleted by the same cascade carry the same deleted_at.
+	query = `WITH RECURSIVE batch AS (
+			SELECT id, deleted_at FROM tasks WHERE id = ?1
+			UNION ALL
+			SELECT t.id, t.deleted_at FROM tasks t JOIN batch b ON t.parent_id = b.id WHERE t.deleted_at = b.deleted_at
+		)
+		UPDATE tasks SET deleted_at = NULL, updated_at = ?2 WHERE id IN (SELECT id FROM batch)`
+	if _, err := tx.ExecContext(ctx, query, id, toDBTime(time.Now())); err != nil {
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	query = `WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE id = ?1
+			UNION ALL
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		SELECT ` + taskColumns + ` FROM tasks WHERE id IN (SELECT id FROM subtree) ORDER BY id = ?1 DESC, created_at, id`
+	tasks, err := queryTasks(ctx, tx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordEvents(ctx, tx, rep.RestoreEvents(ownerID, deleted, tasks)...); err != nil {
+		return nil, err
+	}
+	if err := markReplayed(ctx, tx); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("task_id", id).
+		Int("restored", len(tasks)).
+		Msg("Task restored from trash")
+	return tasks, nil
+}
+
+func (r *trashRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Tasks deleted later than their parent stay in the trash without it.
+	query := `WITH purged AS (
+			SELECT id FROM tasks WHERE deleted_at < ?1 AND (?2 IS NULL OR owner_id = ?2)
+		)
+		UPDATE tasks SET parent_id = NULL WHERE parent_id IN (SELECT id FROM purged) AND id NOT IN (SELECT id FROM purged)`
+	if _, err := tx.ExecContext(ctx, query, toDBTime(before), ownerID); err != nil {
+		return 0, fmt.Errorf("failed to detach subtasks: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM tasks WHERE deleted_at < ?1 AND (?2 IS NULL OR owner_id = ?2)", toDBTime(before), ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge tasks: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count purged tasks: %w", err)
+	}
+	r.log.Info().
+		Int64("purged", purged).
+		Time("before", before).
+		Msg("Trash purged")
+	return int(purged), nil
+}
@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var _ rep.UserRepository = (*userRepository)(nil)
+
+type userRepository struct {
+	db  *sql.DB
+	log zerolog.Logger
+}
+
+func NewUserRepository(db *sql.DB) *userRepository {
+	return &userRepository{
+		db:  db,
+		log: logger.GetLogger("repository.sqlite.user"),
+	}
+}
+
+func (r *userRepository) CreateUser(ctx context.Context, user *model.User) error {
+	user.CreatedAt = time.Now()
+
+	query := "INSERT INTO users (login, password_hash, created_at) VALUES (?1, ?2, ?3) RETURNING id"
+	err := r.db.QueryRowContext(ctx, query, user.Login, user.PasswordHash, toDBTime(user.CreatedAt)).Scan(&user.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("user %q %w", user.Login, model.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	r.log.Info().
+		Int("user_id", user.ID).
+		Str("login", user.Login).
+		Msg("User created successfully")
+	return nil
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id int) (*model.User, error) {
+	query := "SELECT id, login, password_hash, timezone, created_at FROM users WHERE id = ?1"
+
+	var user model.User
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&user.ID, &user.Login, &user.PasswordHash, &user.Timezone, scanTime(&user.CreatedAt))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user with id %d %w", id, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) GetByLogin(ctx context.Context, login string) (*model.User, error) {
+	query := "SELECT id, login, password_hash, timezone, created_at FROM users WHERE login = ?1"
+
+	var user model.User
+	err := r.db.QueryRowContext(ctx, query, login).Scan(&user.ID, &user.Login, &user.PasswordHash, &user.Timezone, scanTime(&user.CreatedAt))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %q %w", login, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) UpdateTimezone(ctx context.Context, id int, timezone string) error {
+	query := "UPDATE users SET timezone = ?1 WHERE id = ?2"
+
+	result, err := r.db.ExecContext(ctx, query, timezone, id)
+	if err != nil {
+		return fmt.Errorf("failed to update user timezone: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("user with id %d %w", id, model.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	taskRepo "techno/internal/repository/task"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+const uniqueViolationCode = "23505"
+
+var _ rep.TagRepository = (*repository)(nil)
+
+type repository struct {
+	pool *pgxpool.Pool
+	log  zerolog.Logger
+}
+
+func NewRepository(pool *pgxpool.Pool) *repository {
+	return &repository{
+		pool: pool,
+		log:  logger.GetLogger("repository.tag"),
+	}
+}
+
+func (r *repository) List(ctx context.Context) ([]*model.Tag, error) {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT tg.id, tg.owner_id, tg.name, COUNT(tt.task_id)
+		FROM tags tg LEFT JOIN task_tags tt ON tt.tag_id = tg.id
+			AND tt.task_id IN (SELECT id FROM tasks WHERE deleted_at IS NULL)
+		WHERE tg.owner_id = $1
+		GROUP BY tg.id, tg.owner_id, tg.name
+		ORDER BY tg.name`
+	rows, err := r.pool.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*model.Tag
+	for rows.Next() {
+		tag := &model.Tag{}
+		if err := rows.Scan(&tag.ID, &tag.OwnerID, &tag.Name, &tag.Tasks); err != nil {
+			return nil, fmt.Errorf("failed scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// Rename updates the tags row, every task sees the new name at once because
+// task_tags references the tag by id. Recurrences and archived tasks keep
+// tag names and are updated in the same transaction.
+func (r *repository) Rename(ctx context.Context, from, to string) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tagged, err := taggedTasks(ctx, tx, ownerID, []string{from})
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(ctx, "UPDATE tags SET name = $1 WHERE owner_id = $2 AND name = $3", to, ownerID, from)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return fmt.Errorf("tag %q %w, merge the tags instead", to, model.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to rename tag: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tag %q %w", from, model.ErrNotFound)
+	}
+
+	if err := retagNames(ctx, tx, ownerID, []string{from}, to); err != nil {
+		return err
+	}
+	if err := recordRetag(ctx, tx, ownerID, tagged); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("owner_id", ownerID).
+		Str("from", from).
+		Str("to", to).
+		Msg("Tag renamed")
+	return nil
+}
+
+// Merge moves every task tagged with one of sources to target and removes
+// the source tags, in one transaction.
+func (r *repository) Merge(ctx context.Context, sources []string, target string) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var found int
+	err = tx.QueryRow(ctx, "SELECT COUNT(*) FROM tags WHERE owner_id = $1 AND name = ANY($2)", ownerID, sources).Scan(&found)
+	if err != nil {
+		return fmt.Errorf("failed to find tags: %w", err)
+	}
+	if found != len(sources) {
+		return fmt.Errorf("tag %w, check the names with `tag list`", model.ErrNotFound)
+	}
+	tagged, err := taggedTasks(ctx, tx, ownerID, sources)
+	if err != nil {
+		return err
+	}
+
+	query := "INSERT INTO tags (owner_id, name) VALUES ($1, $2) ON CONFLICT (owner_id, name) DO NOTHING"
+	if _, err := tx.Exec(ctx, query, ownerID, target); err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	query = `INSERT INTO task_tags (task_id, tag_id)
+		SELECT tt.task_id, t.id FROM task_tags tt
+		JOIN tags s ON s.id = tt.tag_id
+		JOIN tags t ON t.owner_id = s.owner_id AND t.name = $3
+		WHERE s.owner_id = $1 AND s.name = ANY($2)
+		ON CONFLICT DO NOTHING`
+	if _, err := tx.Exec(ctx, query, ownerID, sources, target); err != nil {
+		return fmt.Errorf("failed to retag tasks: %w", err)
+	}
+
+	query = "DELETE FROM tags WHERE owner_id = $1 AND name = ANY($2) AND name <> $3"
+	if _, err := tx.Exec(ctx, query, ownerID, sources, target); err != nil {
+		return fmt.Errorf("failed to delete merged tags: %w", err)
+	}
+
+	if err := retagNames(ctx, tx, ownerID, sources, target); err != nil {
+		return err
+	}
+	if err := recordRetag(ctx, tx, ownerID, tagged); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("owner_id", ownerID).
+		Strs("sources", sources).
+		Str("target", target).
+		Msg("Tags merged")
+	return nil
+}
+
+// Delete removes the tag from every task, archived task and recurrence, the
+// tasks themselves stay.
+func (r *repository) Delete(ctx context.Context, name string) error {
+	ownerID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tagged, err := taggedTasks(ctx, tx, ownerID, []string{name})
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(ctx, "DELETE FROM tags WHERE owner_id = $1 AND name = $2", ownerID, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tag %q %w", name, model.ErrNotFound)
+	}
+
+	if err := retagNames(ctx, tx, ownerID, []string{name}, ""); err != nil {
+		return err
+	}
+	if err := recordRetag(ctx, tx, ownerID, tagged); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("owner_id", ownerID).
+		Str("name", name).
+		Msg("Tag deleted")
+	return nil
+}
+
+// namedTagTables keep tag names instead of task_tags rows.
+var namedTagTables = []string{"recurrences", "tasks_archive"}
+
+// retagNames replaces sources with target in the tag names of the owner's
+// recurrences and archived tasks, keeping them sorted and unique. An empty
+// target only removes them.
+func retagNames(ctx context.Context, tx pgx.Tx, ownerID int, sources []string, target string) error {
+	for _, table := range namedTagTables {
+		query := `UPDATE ` + table + ` SET tags = ARRAY(
+				SELECT DISTINCT CASE WHEN tag = ANY($2) THEN $3 ELSE tag END FROM unnest(tags) tag
+				WHERE NOT (tag = ANY($2) AND $3 = '') ORDER BY 1
+			)
+			WHERE owner_id = $1 AND tags && $2`
+		if _, err := tx.Exec(ctx, query, ownerID, sources, target); err != nil {
+			return fmt.Errorf("failed to retag %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// taggedTasks reads the tags of the owner's tasks that carry one of names,
+// trashed tasks included. Only the fields a tag change touches are set.
+func taggedTasks(ctx context.Context, tx pgx.Tx, ownerID int, names []string) ([]*model.Task, error) {
+	query := "SELECT id, COALESCE(owner_id, 0), " + taskRepo.TagsColumn + ` FROM tasks WHERE id IN (
+			SELECT tt.task_id FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tg.owner_id = $1 AND tg.name = ANY($2)
+		) ORDER BY id`
+	rows, err := tx.Query(ctx, query, ownerID, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tagged tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*model.Task
+	for rows.Next() {
+		task := &model.Task{}
+		if err := rows.Scan(&task.ID, &task.OwnerID, &task.Tags); err != nil {
+			return nil, fmt.Errorf("failed scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// recordRetag records an update event for every task whose tags a tag
+// change touched, in the transaction of the change.
+func recordRetag(ctx context.Context, tx pgx.Tx, actorID int, tagged []*model.Task) error {
+	query := "SELECT " + taskRepo.TagsColumn + " FROM tasks WHERE id = $1"
+	events := make([]*model.TaskEvent, 0, len(tagged))
+	for _, before := range tagged {
+		after := *before
+		if err := tx.QueryRow(ctx, query, before.ID).Scan(&after.Tags); err != nil {
+			return fmt.Errorf("failed to get task %d: %w", before.ID, err)
+		}
+		events = append(events, model.NewTaskEvent(model.TaskUpdated, &actorID, before, &after))
+	}
+	return taskRepo.RecordEvents(ctx, tx, events...)
+}
@@ -0,0 +1,135 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+const eventColumns = "id, task_id, COALESCE(owner_id, 0), actor_id, " +
+	"COALESCE((SELECT login FROM users u WHERE u.id = task_events.actor_id), 'system'), kind, changes, created_at"
+
+var _ rep.EventRepository = (*eventRepository)(nil)
+
+// eventRepository reads the task_events rows the task, trash and archive
+// repositories write in their transactions.
+type eventRepository struct {
+	pool *pgxpool.Pool
+	log  zerolog.Logger
+}
+
+func NewEventRepository(pool *pgxpool.Pool) *eventRepository {
+	return &eventRepository{
+		pool: pool,
+		log:  logger.GetLogger("repository.event"),
+	}
+}
+
+func (r *eventRepository) History(ctx context.Context, taskID int) ([]*model.TaskEvent, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + eventColumns + " FROM task_events WHERE task_id = $1 AND ($2::int IS NULL OR owner_id = $2) ORDER BY created_at, id"
+	events, err := r.queryEvents(ctx, query, taskID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("history of task %d %w", taskID, model.ErrNotFound)
+	}
+	return events, nil
+}
+
+// RecordEvents writes the events of a change in its transaction. Updates
+// that change no tracked field are not recorded.
+func RecordEvents(ctx context.Context, tx pgx.Tx, events ...*model.TaskEvent) error {
+	query := "INSERT INTO task_events (task_id, owner_id, actor_id, kind, changes) VALUES ($1, NULLIF($2, 0), $3, $4, $5::jsonb)"
+	for _, event := range events {
+		if event.Kind == model.TaskUpdated && len(event.Changes) == 0 {
+			continue
+		}
+		changes, err := rep.EncodeChanges(event.Changes)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, query, event.TaskID, event.OwnerID, event.ActorID, event.Kind, changes); err != nil {
+			return fmt.Errorf("failed to record task event: %w", err)
+		}
+	}
+	return nil
+}
+
+// taskImage reads a task in a transaction, whether it is in the trash or
+// not.
+func taskImage(ctx context.Context, tx pgx.Tx, id int) (*model.Task, error) {
+	task, err := scanTask(tx.QueryRow(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id = $1", id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task %d: %w", id, err)
+	}
+	return task, nil
+}
+
+// taskImages reads the tasks a query selects in a transaction.
+func taskImages(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]*model.Task, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed scan task: %w", err)
+	}
+	return tasks, nil
+}
+
+// taskIDs returns the ids of tasks, in their order.
+func taskIDs(tasks []*model.Task) []int {
+	ids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func (r *eventRepository) Until(ctx context.Context, at time.Time) ([]*model.TaskEvent, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + eventColumns + " FROM task_events WHERE created_at <= $1 AND ($2::int IS NULL OR owner_id = $2) ORDER BY created_at, id"
+	return r.queryEvents(ctx, query, at, ownerID)
+}
+
+func (r *eventRepository) queryEvents(ctx context.Context, query string, args ...any) ([]*model.TaskEvent, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*model.TaskEvent
+	for rows.Next() {
+		event := &model.TaskEvent{}
+		var changes []byte
+		if err := rows.Scan(&event.ID, &event.TaskID, &event.OwnerID, &event.ActorID, &event.Actor, &event.Kind, &changes, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed scan task event: %w", err)
+		}
+		if event.Changes, err = rep.DecodeChanges(changes); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
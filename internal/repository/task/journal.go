@@ -0,0 +1,81 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// journal writes the operation of a change in the transaction that makes
+// it, so the user can undo it. It forgets the undone operations, they
+// cannot be redone after a new change. Undo and redo mark the operation
+// they replay instead.
+func journal(ctx context.Context, tx pgx.Tx, op *model.Operation) error {
+	if !rep.Journaled(ctx) {
+		return markReplayed(ctx, tx)
+	}
+	userID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	beforeImage, err := rep.EncodeTaskImage(op.Before)
+	if err != nil {
+		return err
+	}
+	afterImage, err := rep.EncodeTaskImage(op.After)
+	if err != nil {
+		return err
+	}
+	subtaskImages, err := rep.EncodeSubtaskImages(op.Subtasks)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM operations WHERE user_id = $1 AND undone_at IS NOT NULL", userID); err != nil {
+		return fmt.Errorf("failed to forget undone operations: %w", err)
+	}
+
+	query := "INSERT INTO operations (user_id, kind, task_id, before_image, after_image, child_policy, subtask_images) VALUES ($1, $2, $3, $4::jsonb, $5::jsonb, $6, $7::jsonb)"
+	_, err = tx.Exec(ctx, query, userID, op.Kind, op.Task().ID, jsonParam(beforeImage), jsonParam(afterImage), op.Policy, subtaskImages)
+	if err != nil {
+		return fmt.Errorf("failed to record operation: %w", err)
+	}
+	return nil
+}
+
+// markReplayed marks the operation an undo or a redo replays, if the
+// change is one. The operation must still be in the state the replay
+// expects, so two concurrent undos cannot both apply it.
+func markReplayed(ctx context.Context, tx pgx.Tx) error {
+	replay, ok := rep.ReplayFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	userID, err := rep.UserScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := "UPDATE operations SET undone_at = CASE WHEN $3 THEN CURRENT_TIMESTAMP END WHERE id = $1 AND user_id = $2 AND (undone_at IS NULL) = $3"
+	result, err := tx.Exec(ctx, query, replay.OperationID, userID, replay.Undo)
+	if err != nil {
+		return fmt.Errorf("failed to update operation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return rep.ReplayConflict(replay)
+	}
+	return nil
+}
+
+// jsonParam sends nil as NULL instead of an empty string.
+func jsonParam(data []byte) *string {
+	if data == nil {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
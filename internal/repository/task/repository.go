@@ -4,16 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"techno/internal/config/logger"
 	"techno/internal/model"
 	rep "techno/internal/repository"
 	"time"
 
-	"github.com/jackc/pgx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 )
 
+const uniqueViolationCode = "23505"
+
+var taskColumns = "id, COALESCE(owner_id, 0), title, description, status, priority, due_at, created_at, updated_at, completed_at, deleted_at, parent_id, recurrence_id, occurrence_at, " +
+	"project_id, COALESCE(project_number, 0), COALESCE((SELECT key FROM projects p WHERE p.id = tasks.project_id), ''), " +
+	openDependenciesColumn + ", " + TagsColumn
+
+// openDependenciesColumn counts the unfinished tasks the selected task
+// depends on. Tasks in the trash do not hold anything up.
+var openDependenciesColumn = fmt.Sprintf("(SELECT COUNT(*) FROM task_dependencies d JOIN tasks dt ON dt.id = d.depends_on_id "+
+	"WHERE d.task_id = tasks.id AND dt.deleted_at IS NULL AND dt.status NOT IN (%d, %d))", model.Closed, model.Cancelled)
+
+// TagsColumn collects the tag names of the selected task row. The tag
+// repository reads the tags of the tasks a tag change touches with it.
+const TagsColumn = "COALESCE((SELECT array_agg(tg.name ORDER BY tg.name) FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id), '{}')"
+
 var _ rep.TaskRepository = (*repository)(nil)
 
 type repository struct {
@@ -31,8 +49,20 @@ func NewRepository(pool *pgxpool.Pool) *repository {
 func (r *repository) CreateTask(ctx context.Context, task *model.Task) error {
 	start := time.Now()
 
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+	if ownerID != nil {
+		task.OwnerID = *ownerID
+	}
+	if task.OwnerID <= 0 {
+		return errors.New("task owner is required")
+	}
+
 	r.log.Info().
 		Str("title", task.Title).
+		Int("owner_id", task.OwnerID).
 		Int("desc_len", len(task.Description)).
 		Msg("Creating task")
 	tx, err := r.pool.Begin(ctx)
@@ -41,11 +71,37 @@ func (r *repository) CreateTask(ctx context.Context, task *model.Task) error {
 	}
 	defer tx.Rollback(ctx)
 
-	query := "INSERT INTO tasks (title, description) VALUES ($1, $2) RETURNING id, status, created_at"
-	err = tx.QueryRow(ctx, query, task.Title, task.Description).Scan(&task.ID, &task.Status, &task.CreatedAt)
+	var number *int
+	if task.ProjectID != nil {
+		if err := assignNumber(ctx, tx, task, task.OwnerID); err != nil {
+			return err
+		}
+		number = &task.Number
+	}
+
+	query := "INSERT INTO tasks (owner_id, title, description, priority, due_at, project_id, project_number, parent_id, recurrence_id, occurrence_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, status, created_at, updated_at"
+	err = tx.QueryRow(ctx, query, task.OwnerID, task.Title, task.Description, task.Priority, task.DueAt, task.ProjectID, number, task.ParentID, task.RecurrenceID, task.OccurrenceAt).Scan(&task.ID, &task.Status, &task.CreatedAt, &task.UpdatedAt)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && task.RecurrenceID != nil {
+			return fmt.Errorf("occurrence of recurrence %d at %s %w", *task.RecurrenceID, task.OccurrenceAt.Format(time.RFC3339), model.ErrAlreadyExists)
+		}
 		return fmt.Errorf("failed created task: %w", err)
 	}
+	if err := replaceTags(ctx, tx, task); err != nil {
+		return err
+	}
+	// The owner scope is the user making the change, nil for the system.
+	created, err := taskImage(ctx, tx, task.ID)
+	if err != nil {
+		return err
+	}
+	if err := RecordEvents(ctx, tx, model.NewTaskEvent(model.TaskCreated, ownerID, nil, created)); err != nil {
+		return err
+	}
+	if err := journal(ctx, tx, &model.Operation{Kind: model.OperationCreate, After: created}); err != nil {
+		return err
+	}
 	if err := tx.Commit(ctx); err != nil {
 		r.log.Error().Err(err).Int("task_id", task.ID).Msg("failed to commit transaction")
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -61,72 +117,109 @@ func (r *repository) CreateTask(ctx context.Context, task *model.Task) error {
 }
 
 func (r *repository) GetByID(ctx context.Context, id int) (*model.Task, error) {
-	query := "SELECT * FROM tasks WHERE id = $1"
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	var task model.Task
-	err := r.pool.QueryRow(ctx, query, id).Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.CreatedAt)
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = $1 AND ($2::int IS NULL OR owner_id = $2) AND deleted_at IS NULL"
+
+	task, err := scanTask(r.pool.QueryRow(ctx, query, id, ownerID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("task with id %d not found", id)
+			return nil, fmt.Errorf("task with id %d %w", id, model.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
-	return &task, nil
+	return task, nil
+}
+
+func (r *repository) GetByProjectNumber(ctx context.Context, projectKey string, number int) (*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE project_number = $2 AND ($3::int IS NULL OR owner_id = $3) AND deleted_at IS NULL" +
+		" AND project_id IN (SELECT id FROM projects WHERE key = $1 AND owner_id = tasks.owner_id)"
+
+	task, err := scanTask(r.pool.QueryRow(ctx, query, projectKey, number, ownerID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("task %s-%d %w", projectKey, number, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	return task, nil
+}
+
+func (r *repository) GetSubtasks(ctx context.Context, id int) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE parent_id = $1 AND ($2::int IS NULL OR owner_id = $2) AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		SELECT ` + taskColumns + ` FROM tasks WHERE id IN (SELECT id FROM subtree) ORDER BY created_at, id`
+	rows, err := r.pool.Query(ctx, query, id, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed scan task: %w", err)
+	}
+	return tasks, nil
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]*model.Task, error) {
-	rows, err := r.pool.Query(ctx, "SELECT * FROM tasks ORDER BY created_at DESC")
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE ($1::int IS NULL OR owner_id = $1) AND deleted_at IS NULL ORDER BY created_at DESC"
+	rows, err := r.pool.Query(ctx, query, ownerID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var tasks []*model.Task
-	for rows.Next() {
-		task := &model.Task{}
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("error: %w", err)
-		}
-		tasks = append(tasks, task)
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("error: %w", err)
 	}
 
 	return tasks, nil
 }
 
 func (r *repository) GetByStatus(ctx context.Context, status model.TaskStatus) ([]*model.Task, error) {
-	query := "SELECT * FROM tasks WHERE status = $1 ORDER BY created_at DESC"
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE status = $1 AND ($2::int IS NULL OR owner_id = $2) AND deleted_at IS NULL ORDER BY created_at DESC"
 	start := time.Now()
-	rows, err := r.pool.Query(ctx, query, status)
+	rows, err := r.pool.Query(ctx, query, status, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed get task by status: %w", err)
 	}
 
 	defer rows.Close()
 
-	var tasks []*model.Task
-	for rows.Next() {
-		task := &model.Task{}
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed scan task: %w", err)
-		}
-		tasks = append(tasks, task)
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed scan task: %w", err)
 	}
 	r.log.Info().
-		Str("status", string(status)).
+		Str("status", status.StringStatus()).
 		Int("count", len(tasks)).
 		Dur("duration", time.Since(start)).
 		Msg("Retrieved tasks by status")
@@ -134,9 +227,75 @@ func (r *repository) GetByStatus(ctx context.Context, status model.TaskStatus) (
 	return tasks, nil
 }
 
-func (r *repository) UpdateTask(ctx context.Context, task *model.Task) error {
+func (r *repository) List(ctx context.Context, filter model.TaskFilter) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []any{ownerID}
+	conditions := []string{"($1::int IS NULL OR owner_id = $1)", "deleted_at IS NULL"}
+	addCondition := func(format string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.Status != nil {
+		addCondition("status = $%d", *filter.Status)
+	}
+	if filter.Overdue {
+		addCondition("due_at < $%d", time.Now())
+		conditions = append(conditions, fmt.Sprintf("status NOT IN (%d, %d)", model.Closed, model.Cancelled))
+	}
+	if filter.DueBefore != nil {
+		addCondition("due_at < $%d", *filter.DueBefore)
+	}
+	if filter.DueAfter != nil {
+		addCondition("due_at > $%d", *filter.DueAfter)
+	}
+	if filter.Project != "" {
+		addCondition("project_id IN (SELECT id FROM projects WHERE key = $%d AND owner_id = tasks.owner_id)", filter.Project)
+	} else {
+		conditions = append(conditions, "(project_id IS NULL OR project_id IN (SELECT id FROM projects WHERE archived_at IS NULL))")
+	}
+	if len(filter.Tags) > 0 {
+		tagged := "SELECT COUNT(*) FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id AND tg.name = ANY($%d)"
+		if filter.AllTags {
+			addCondition("("+tagged+") = "+strconv.Itoa(len(filter.Tags)), filter.Tags)
+		} else {
+			addCondition("("+tagged+") > 0", filter.Tags)
+		}
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE " + strings.Join(conditions, " AND ") + " ORDER BY " + rep.OrderBy(filter.Sort)
+
+	start := time.Now()
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed scan task: %w", err)
+	}
+
+	r.log.Debug().
+		Int("count", len(tasks)).
+		Dur("duration", time.Since(start)).
+		Msg("Listed tasks")
+	return tasks, nil
+}
+
+func (r *repository) UpdateTask(ctx context.Context, task *model.Task, expected *model.Task) error {
 	start := time.Now()
 
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
 	r.log.Info().
 		Int("task_id", task.ID).
 		Str("new_title", task.Title).
@@ -147,18 +306,61 @@ func (r *repository) UpdateTask(ctx context.Context, task *model.Task) error {
 	}
 	defer tx.Rollback(ctx)
 
-	query := "UPDATE tasks SET title = $1, description = $2, status = $3 WHERE id = $4"
+	var taskOwner int
+	var currentProject, currentNumber *int
+	query := "SELECT owner_id, project_id, project_number FROM tasks WHERE id = $1 AND ($2::int IS NULL OR owner_id = $2) AND deleted_at IS NULL FOR UPDATE"
+	if err := tx.QueryRow(ctx, query, task.ID, ownerID).Scan(&taskOwner, &currentProject, &currentNumber); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("task with id %d %w", task.ID, model.ErrNotFound)
+		}
+		return fmt.Errorf("failed to lock task: %w", err)
+	}
+	before, err := taskImage(ctx, tx, task.ID)
+	if err != nil {
+		return err
+	}
+	if expected != nil && !model.SameState(before, expected) {
+		return fmt.Errorf("%w: task %d was changed since it was read", model.ErrConflict, task.ID)
+	}
+
+	// A task keeps its number while it stays in the same project and gets
+	// the next number of the project it moves to.
+	number := currentNumber
+	switch {
+	case task.ProjectID == nil:
+		number = nil
+		task.ProjectKey, task.Number = "", 0
+	case currentProject == nil || *currentProject != *task.ProjectID:
+		if err := assignNumber(ctx, tx, task, taskOwner); err != nil {
+			return err
+		}
+		number = &task.Number
+	}
 
-	result, err := tx.Exec(ctx, query, task.Title, task.Description, task.Status, task.ID)
+	// completed_at keeps the first finish time while a task moves between
+	// finished statuses and is cleared when it is reopened.
+	query = "UPDATE tasks SET title = $1, description = $2, status = $3, priority = $4, due_at = $5, project_id = $6, project_number = $7, parent_id = $8, " +
+		"updated_at = CURRENT_TIMESTAMP, completed_at = " + completedAtExpr("$3::int", "CURRENT_TIMESTAMP") + " WHERE id = $9 RETURNING updated_at, completed_at"
+
+	err = tx.QueryRow(ctx, query, task.Title, task.Description, task.Status, task.Priority, task.DueAt, task.ProjectID, number, task.ParentID, task.ID).Scan(&task.UpdatedAt, &task.CompletedAt)
 	if err != nil {
 		return fmt.Errorf("failed to update task: %w", err)
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("task with id %d not found", task.ID)
+	if err := replaceTags(ctx, tx, task); err != nil {
+		return err
 	}
 
+	after, err := taskImage(ctx, tx, task.ID)
+	if err != nil {
+		return err
+	}
+	if err := RecordEvents(ctx, tx, model.NewTaskEvent(model.TaskUpdated, ownerID, before, after)); err != nil {
+		return err
+	}
+	if err := journal(ctx, tx, &model.Operation{Kind: model.OperationUpdate, Before: before, After: after}); err != nil {
+		return err
+	}
 	if err := tx.Commit(ctx); err != nil {
 		r.log.Error().Err(err).Int("task_id", task.ID).Msg("failed to commit transaction")
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -173,26 +375,110 @@ func (r *repository) UpdateTask(ctx context.Context, task *model.Task) error {
 	return nil
 }
 
-func (r *repository) DeleteTask(ctx context.Context, id int) error {
+func (r *repository) DeleteTask(ctx context.Context, id int, policy model.ChildPolicy, expected *model.Task, subtasks []*model.Task) error {
 	start := time.Now()
+
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	query := "DELETE FROM tasks WHERE id = $1"
+	var parentID *int
+	var children int
+	query := "SELECT parent_id, (SELECT COUNT(*) FROM tasks c WHERE c.parent_id = tasks.id AND c.deleted_at IS NULL) FROM tasks " +
+		"WHERE id = $1 AND ($2::int IS NULL OR owner_id = $2) AND deleted_at IS NULL FOR UPDATE"
+	if err := tx.QueryRow(ctx, query, id, ownerID).Scan(&parentID, &children); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("task with id %d %w", id, model.ErrNotFound)
+		}
+		return fmt.Errorf("failed to lock task: %w", err)
+	}
+	before, err := taskImage(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if expected != nil && !model.SameState(before, expected) {
+		return fmt.Errorf("%w: task %d was changed since it was read", model.ErrConflict, id)
+	}
 
-	result, err := tx.Exec(ctx, query, id)
+	// The subtasks the policy touches are read and locked first, the
+	// journal keeps their images and a redo has to find the same ones.
+	var touched []*model.Task
+	switch {
+	case children == 0:
+	case policy == model.ChildrenCascade:
+		query = `WITH RECURSIVE subtree AS (
+				SELECT id FROM tasks WHERE parent_id = $1 AND deleted_at IS NULL
+				UNION ALL
+				SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+			)
+			SELECT ` + taskColumns + ` FROM tasks WHERE id IN (SELECT id FROM subtree) ORDER BY id FOR UPDATE`
+		touched, err = taskImages(ctx, tx, query, id)
+	case policy == model.ChildrenReparent:
+		touched, err = taskImages(ctx, tx, "SELECT "+taskColumns+" FROM tasks WHERE parent_id = $1 AND deleted_at IS NULL ORDER BY id FOR UPDATE", id)
+	default:
+		return fmt.Errorf("task %d has %d subtask(s): %w", id, children, model.ErrHasSubtasks)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
+		return err
+	}
+	if expected != nil && !model.SameTasks(touched, subtasks) {
+		return fmt.Errorf("%w: subtasks of task %d were changed since it was deleted", model.ErrConflict, id)
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("task with id %d not found", id)
+	// Deleting moves tasks to the trash. CURRENT_TIMESTAMP is the same for
+	// the whole transaction, so a cascade shares one deleted_at.
+	op := &model.Operation{Kind: model.OperationDelete, Before: before, Policy: policy}
+	trashed := []int{id}
+	var events []*model.TaskEvent
+	if policy == model.ChildrenReparent && len(touched) > 0 {
+		if _, err := tx.Exec(ctx, "UPDATE tasks SET parent_id = $1, updated_at = CURRENT_TIMESTAMP WHERE id = ANY($2)", parentID, taskIDs(touched)); err != nil {
+			return fmt.Errorf("failed to move subtasks: %w", err)
+		}
+		for _, child := range touched {
+			after := *child
+			after.ParentID = parentID
+			op.Subtasks = append(op.Subtasks, model.SubtaskChange{Before: child, After: &after})
+			events = append(events, model.NewTaskEvent(model.TaskUpdated, ownerID, child, &after))
+		}
+	} else {
+		for _, sub := range touched {
+			op.Subtasks = append(op.Subtasks, model.SubtaskChange{Before: sub})
+		}
+		trashed = append(trashed, taskIDs(touched)...)
 	}
 
+	rows, err := tx.Query(ctx, "UPDATE tasks SET deleted_at = CURRENT_TIMESTAMP WHERE id = ANY($1) RETURNING id, COALESCE(owner_id, 0)", trashed)
+	if err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	rowsAffected := 0
+	for rows.Next() {
+		event := &model.TaskEvent{Kind: model.TaskDeleted, ActorID: ownerID}
+		if err := rows.Scan(&event.TaskID, &event.OwnerID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to delete task: %w", err)
+		}
+		events = append(events, event)
+		rowsAffected++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	if err := RecordEvents(ctx, tx, events...); err != nil {
+		return err
+	}
+	if err := journal(ctx, tx, op); err != nil {
+		return err
+	}
 	if err := tx.Commit(ctx); err != nil {
 		r.log.Error().Err(err).Int("task_id", id).Msg("Failed to commit transaction")
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -200,8 +486,189 @@ func (r *repository) DeleteTask(ctx context.Context, id int) error {
 
 	r.log.Info().
 		Int("task_id", id).
-		Int64("rows_affected", rowsAffected).
+		Int("rows_affected", rowsAffected).
+		Str("children", policy.String()).
 		Dur("duration", time.Since(start)).
 		Msg("Task deleted successfully")
 	return nil
 }
+
+func (r *repository) UndeleteTask(ctx context.Context, op *model.Operation) error {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var parentDeleted bool
+	query := "SELECT COALESCE((SELECT p.deleted_at IS NOT NULL FROM tasks p WHERE p.id = tasks.parent_id), false) FROM tasks " +
+		"WHERE id = $1 AND ($2::int IS NULL OR owner_id = $2) AND deleted_at IS NOT NULL FOR UPDATE"
+	if err := tx.QueryRow(ctx, query, op.TaskID, ownerID).Scan(&parentDeleted); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("deleted task with id %d %w", op.TaskID, model.ErrNotFound)
+		}
+		return fmt.Errorf("failed to lock task: %w", err)
+	}
+	if parentDeleted {
+		return fmt.Errorf("%w: the parent of task %d is in the trash", model.ErrConflict, op.TaskID)
+	}
+	deleted, err := taskImage(ctx, tx, op.TaskID)
+	if err != nil {
+		return err
+	}
+	if !model.SameState(deleted, op.Before) {
+		return fmt.Errorf("%w: task %d was changed since it was deleted", model.ErrConflict, op.TaskID)
+	}
+
+	// Every subtask must still be where the delete left it: in the trash,
+	// or live under the new parent.
+	restored := []int{op.TaskID}
+	var moved []*model.Task
+	for _, sub := range op.Subtasks {
+		current, err := scanTask(tx.QueryRow(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id = $1 FOR UPDATE", sub.Before.ID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: subtask %d no longer exists", model.ErrConflict, sub.Before.ID)
+			}
+			return fmt.Errorf("failed to lock subtask: %w", err)
+		}
+		if sub.After == nil {
+			if current.DeletedAt == nil || !model.SameState(current, sub.Before) {
+				return fmt.Errorf("%w: subtask %d was changed since it was deleted", model.ErrConflict, current.ID)
+			}
+			restored = append(restored, current.ID)
+			continue
+		}
+		if current.DeletedAt != nil || !model.SameState(current, sub.After) {
+			return fmt.Errorf("%w: subtask %d was changed since it was moved", model.ErrConflict, current.ID)
+		}
+		moved = append(moved, current)
+	}
+
+	query = "UPDATE tasks SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = ANY($1)"
+	if _, err := tx.Exec(ctx, query, restored); err != nil {
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+	query = "UPDATE tasks SET parent_id = $1, updated_at = CURRENT_TIMESTAMP WHERE id = ANY($2)"
+	if _, err := tx.Exec(ctx, query, op.TaskID, taskIDs(moved)); err != nil {
+		return fmt.Errorf("failed to move subtasks back: %w", err)
+	}
+
+	tasks, err := taskImages(ctx, tx, "SELECT "+taskColumns+" FROM tasks WHERE id = ANY($1) ORDER BY id = $2 DESC, created_at, id", restored, op.TaskID)
+	if err != nil {
+		return err
+	}
+	events := rep.RestoreEvents(ownerID, deleted, tasks)
+	for _, child := range moved {
+		after := *child
+		after.ParentID = &op.TaskID
+		events = append(events, model.NewTaskEvent(model.TaskUpdated, ownerID, child, &after))
+	}
+	if err := RecordEvents(ctx, tx, events...); err != nil {
+		return err
+	}
+	if err := markReplayed(ctx, tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("task_id", op.TaskID).
+		Int("restored", len(restored)).
+		Int("moved_back", len(moved)).
+		Msg("Task delete undone")
+	return nil
+}
+
+// completedAtExpr computes completed_at for a row getting the new status.
+func completedAtExpr(status, now string) string {
+	return fmt.Sprintf("CASE WHEN %s IN (%d, %d) THEN COALESCE(completed_at, %s) ELSE NULL END", status, model.Closed, model.Cancelled, now)
+}
+
+// assignNumber takes the next number of task.ProjectID, which has to belong
+// to ownerID, and fills task.Number and task.ProjectKey.
+func assignNumber(ctx context.Context, tx pgx.Tx, task *model.Task, ownerID int) error {
+	query := "UPDATE projects SET last_number = last_number + 1 WHERE id = $1 AND owner_id = $2 RETURNING key, last_number"
+	err := tx.QueryRow(ctx, query, *task.ProjectID, ownerID).Scan(&task.ProjectKey, &task.Number)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("project with id %d %w", *task.ProjectID, model.ErrNotFound)
+		}
+		return fmt.Errorf("failed to number task: %w", err)
+	}
+	return nil
+}
+
+// replaceTags makes task.Tags the exact tag set of the task, creating the
+// tags its owner does not have yet.
+func replaceTags(ctx context.Context, tx pgx.Tx, task *model.Task) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM task_tags WHERE task_id = $1", task.ID); err != nil {
+		return fmt.Errorf("failed to clear task tags: %w", err)
+	}
+	if len(task.Tags) == 0 {
+		return nil
+	}
+
+	query := `INSERT INTO tags (owner_id, name)
+		SELECT t.owner_id, n.name FROM tasks t, unnest($2::text[]) AS n(name) WHERE t.id = $1
+		ON CONFLICT (owner_id, name) DO NOTHING`
+	if _, err := tx.Exec(ctx, query, task.ID, task.Tags); err != nil {
+		return fmt.Errorf("failed to create tags: %w", err)
+	}
+
+	query = `INSERT INTO task_tags (task_id, tag_id)
+		SELECT t.id, tg.id FROM tasks t JOIN tags tg ON tg.owner_id = t.owner_id
+		WHERE t.id = $1 AND tg.name = ANY($2)`
+	if _, err := tx.Exec(ctx, query, task.ID, task.Tags); err != nil {
+		return fmt.Errorf("failed to tag task: %w", err)
+	}
+	return nil
+}
+
+func scanTask(row pgx.Row) (*model.Task, error) {
+	task := &model.Task{}
+	err := row.Scan(
+		&task.ID,
+		&task.OwnerID,
+		&task.Title,
+		&task.Description,
+		&task.Status,
+		&task.Priority,
+		&task.DueAt,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&task.CompletedAt,
+		&task.DeletedAt,
+		&task.ParentID,
+		&task.RecurrenceID,
+		&task.OccurrenceAt,
+		&task.ProjectID,
+		&task.Number,
+		&task.ProjectKey,
+		&task.OpenDependencies,
+		&task.Tags,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func scanTasks(rows pgx.Rows) ([]*model.Task, error) {
+	var tasks []*model.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
@@ -0,0 +1,163 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+var _ rep.TrashRepository = (*trashRepository)(nil)
+
+// trashRepository reads the same rows as the task repository, the ones
+// with deleted_at set.
+type trashRepository struct {
+	pool *pgxpool.Pool
+	log  zerolog.Logger
+}
+
+func NewTrashRepository(pool *pgxpool.Pool) *trashRepository {
+	return &trashRepository{
+		pool: pool,
+		log:  logger.GetLogger("repository.trash"),
+	}
+}
+
+func (r *trashRepository) List(ctx context.Context) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE deleted_at IS NOT NULL AND ($1::int IS NULL OR owner_id = $1) ORDER BY deleted_at DESC, id DESC"
+	rows, err := r.pool.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed scan task: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *trashRepository) Restore(ctx context.Context, id int) ([]*model.Task, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var parentDeleted bool
+	query := "SELECT COALESCE((SELECT p.deleted_at IS NOT NULL FROM tasks p WHERE p.id = tasks.parent_id), false) FROM tasks " +
+		"WHERE id = $1 AND ($2::int IS NULL OR owner_id = $2) AND deleted_at IS NOT NULL FOR UPDATE"
+	if err := tx.QueryRow(ctx, query, id, ownerID).Scan(&parentDeleted); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("deleted task with id %d %w", id, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to lock task: %w", err)
+	}
+	deleted, err := taskImage(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if parentDeleted {
+		if _, err := tx.Exec(ctx, "UPDATE tasks SET parent_id = NULL WHERE id = $1", id); err != nil {
+			return nil, fmt.Errorf("failed to detach task from its parent: %w", err)
+		}
+	}
+
+	// Subtasks deleted by the same cascade carry the same deleted_at.
+	query = `WITH RECURSIVE batch AS (
+			SELECT id, deleted_at FROM tasks WHERE id = $1
+			UNION ALL
+			SELECT t.id, t.deleted_at FROM tasks t JOIN batch b ON t.parent_id = b.id WHERE t.deleted_at = b.deleted_at
+		)
+		UPDATE tasks SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id IN (SELECT id FROM batch)`
+	if _, err := tx.Exec(ctx, query, id); err != nil {
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	query = `WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE id = $1
+			UNION ALL
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		SELECT ` + taskColumns + ` FROM tasks WHERE id IN (SELECT id FROM subtree) ORDER BY id = $1 DESC, created_at, id`
+	rows, err := tx.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get restored tasks: %w", err)
+	}
+	tasks, err := scanTasks(rows)
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed scan task: %w", err)
+	}
+
+	if err := RecordEvents(ctx, tx, rep.RestoreEvents(ownerID, deleted, tasks)...); err != nil {
+		return nil, err
+	}
+	if err := markReplayed(ctx, tx); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info().
+		Int("task_id", id).
+		Int("restored", len(tasks)).
+		Msg("Task restored from trash")
+	return tasks, nil
+}
+
+func (r *trashRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	ownerID, err := rep.OwnerScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Tasks deleted later than their parent stay in the trash without it.
+	query := `WITH purged AS (
+			SELECT id FROM tasks WHERE deleted_at < $1 AND ($2::int IS NULL OR owner_id = $2)
+		)
+		UPDATE tasks SET parent_id = NULL WHERE parent_id IN (SELECT id FROM purged) AND id NOT IN (SELECT id FROM purged)`
+	if _, err := tx.Exec(ctx, query, before, ownerID); err != nil {
+		return 0, fmt.Errorf("failed to detach subtasks: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, "DELETE FROM tasks WHERE deleted_at < $1 AND ($2::int IS NULL OR owner_id = $2)", before, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge tasks: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	purged := int(result.RowsAffected())
+	r.log.Info().
+		Int("purged", purged).
+		Time("before", before).
+		Msg("Trash purged")
+	return purged, nil
+}
@@ -0,0 +1,91 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"techno/internal/config/logger"
+	"techno/internal/model"
+	rep "techno/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+const uniqueViolationCode = "23505"
+
+var _ rep.UserRepository = (*repository)(nil)
+
+type repository struct {
+	pool *pgxpool.Pool
+	log  zerolog.Logger
+}
+
+func NewRepository(pool *pgxpool.Pool) *repository {
+	return &repository{
+		pool: pool,
+		log:  logger.GetLogger("repository.user"),
+	}
+}
+
+func (r *repository) CreateUser(ctx context.Context, user *model.User) error {
+	query := "INSERT INTO users (login, password_hash) VALUES ($1, $2) RETURNING id, created_at"
+
+	err := r.pool.QueryRow(ctx, query, user.Login, user.PasswordHash).Scan(&user.ID, &user.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return fmt.Errorf("user %q %w", user.Login, model.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	r.log.Info().
+		Int("user_id", user.ID).
+		Str("login", user.Login).
+		Msg("User created successfully")
+	return nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id int) (*model.User, error) {
+	query := "SELECT id, login, password_hash, timezone, created_at FROM users WHERE id = $1"
+
+	var user model.User
+	err := r.pool.QueryRow(ctx, query, id).Scan(&user.ID, &user.Login, &user.PasswordHash, &user.Timezone, &user.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("user with id %d %w", id, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *repository) GetByLogin(ctx context.Context, login string) (*model.User, error) {
+	query := "SELECT id, login, password_hash, timezone, created_at FROM users WHERE login = $1"
+
+	var user model.User
+	err := r.pool.QueryRow(ctx, query, login).Scan(&user.ID, &user.Login, &user.PasswordHash, &user.Timezone, &user.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("user %q %w", login, model.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *repository) UpdateTimezone(ctx context.Context, id int, timezone string) error {
+	query := "UPDATE users SET timezone = $1 WHERE id = $2"
+
+	result, err := r.pool.Exec(ctx, query, timezone, id)
+	if err != nil {
+		return fmt.Errorf("failed to update user timezone: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user with id %d %w", id, model.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"techno/internal/model"
+)
+
+func (s *service) Archive(ctx context.Context, id int) error {
+	if id <= 0 {
+		return fmt.Errorf("%w: invalid task id %d", model.ErrInvalidInput, id)
+	}
+
+	task, err := s.taskRepository.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("task not found: %w", err)
+	}
+	if !task.Status.IsFinished() {
+		return fmt.Errorf("%w: task %s is %s, only finished tasks are archived",
+			model.ErrInvalidInput, task.DisplayID(), task.Status.StringStatus())
+	}
+
+	if err := s.archiveRepository.Archive(ctx, id); err != nil {
+		return fmt.Errorf("failed to archive task: %w", err)
+	}
+	return nil
+}
+
+func (s *service) List(ctx context.Context) ([]*model.ArchivedTask, error) {
+	return s.Search(ctx, "")
+}
+
+func (s *service) Search(ctx context.Context, query string) ([]*model.ArchivedTask, error) {
+	tasks, err := s.archiveRepository.List(ctx, strings.TrimSpace(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived tasks: %w", err)
+	}
+
+	if tasks == nil {
+		return []*model.ArchivedTask{}, nil
+	}
+	return tasks, nil
+}
+
+func (s *service) Restore(ctx context.Context, id int) (*model.Task, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("%w: invalid task id %d", model.ErrInvalidInput, id)
+	}
+
+	task, err := s.archiveRepository.Restore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+	return task, nil
+}
@@ -0,0 +1,23 @@
+package archive
+
+import (
+	"techno/internal/repository"
+	def "techno/internal/service"
+)
+
+var _ def.ArchiveService = (*service)(nil)
+
+type service struct {
+	archiveRepository repository.ArchiveRepository
+	taskRepository    repository.TaskRepository
+}
+
+func NewService(
+	archiveRepository repository.ArchiveRepository,
+	taskRepository repository.TaskRepository,
+) *service {
+	return &service{
+		archiveRepository: archiveRepository,
+		taskRepository:    taskRepository,
+	}
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"techno/internal/auth"
+	"techno/internal/model"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minPasswordLen = 8
+	maxLoginLen    = 64
+	tokenBytes     = 32
+)
+
+func (s *service) Register(ctx context.Context, login, password string) (*model.User, error) {
+	login = strings.TrimSpace(login)
+	if login == "" || len(login) > maxLoginLen {
+		return nil, fmt.Errorf("login must be between 1 and %d characters", maxLoginLen)
+	}
+	if len(password) < minPasswordLen {
+		return nil, fmt.Errorf("password must be at least %d characters", minPasswordLen)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &model.User{
+		Login:        login,
+		PasswordHash: string(hash),
+	}
+	if err := s.userRepository.CreateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to register user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *service) Login(ctx context.Context, login, password string) (string, error) {
+	user, err := s.userRepository.GetByLogin(ctx, strings.TrimSpace(login))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return "", model.ErrInvalidCredentials
+		}
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return "", model.ErrInvalidCredentials
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+
+	session := &model.Session{
+		TokenHash: hashToken(token),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(sessionTTL),
+	}
+	if err := s.sessionRepository.CreateSession(ctx, session); err != nil {
+		return "", fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return token, nil
+}
+
+func (s *service) Logout(ctx context.Context, token string) error {
+	if token == "" {
+		return nil
+	}
+
+	if err := s.sessionRepository.DeleteSession(ctx, hashToken(token)); err != nil {
+		return fmt.Errorf("failed to logout: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Authenticate(ctx context.Context, token string) (*model.User, error) {
+	if token == "" {
+		return nil, model.ErrUnauthenticated
+	}
+
+	session, err := s.sessionRepository.GetByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, model.ErrUnauthenticated
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session.Expired(time.Now()) {
+		_ = s.sessionRepository.DeleteSession(ctx, session.TokenHash)
+		return nil, model.ErrUnauthenticated
+	}
+
+	user, err := s.userRepository.GetByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, model.ErrUnauthenticated
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetTimezone stores the IANA zone the current user works in. An empty
+// timezone falls back to the application default.
+func (s *service) SetTimezone(ctx context.Context, timezone string) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.IsSystem() {
+		return model.ErrUnauthenticated
+	}
+
+	timezone = strings.TrimSpace(timezone)
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("%w: unknown timezone %q", model.ErrInvalidInput, timezone)
+		}
+	}
+
+	if err := s.userRepository.UpdateTimezone(ctx, principal.UserID, timezone); err != nil {
+		return fmt.Errorf("failed to set timezone: %w", err)
+	}
+	return nil
+}
+
+func newToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate session token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashToken is what gets stored in the sessions table, so a leaked database
+// dump cannot be used to impersonate users.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"techno/internal/repository"
+	def "techno/internal/service"
+	"time"
+)
+
+const sessionTTL = 30 * 24 * time.Hour
+
+var _ def.AuthService = (*service)(nil)
+
+type service struct {
+	userRepository    repository.UserRepository
+	sessionRepository repository.SessionRepository
+}
+
+func NewService(userRepository repository.UserRepository, sessionRepository repository.SessionRepository) *service {
+	return &service{
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+	}
+}
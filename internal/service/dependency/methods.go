@@ -0,0 +1,137 @@
+package dependency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"techno/internal/auth"
+	"techno/internal/model"
+)
+
+func requirePrincipal(ctx context.Context) error {
+	if _, ok := auth.FromContext(ctx); !ok {
+		return model.ErrUnauthenticated
+	}
+	return nil
+}
+
+func (s *service) Add(ctx context.Context, taskID, dependsOnID int) error {
+	if err := requirePrincipal(ctx); err != nil {
+		return err
+	}
+	if taskID == dependsOnID {
+		return fmt.Errorf("%w: task cannot depend on itself", model.ErrInvalidInput)
+	}
+
+	task, err := s.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("task not found: %w", err)
+	}
+	dependsOn, err := s.taskRepository.GetByID(ctx, dependsOnID)
+	if err != nil {
+		return fmt.Errorf("task not found: %w", err)
+	}
+
+	// The repository checks the graph in the transaction that adds the
+	// edge, two concurrent adds cannot close a cycle.
+	err = s.dependencyRepository.Add(ctx, taskID, dependsOnID)
+	var cycle *model.CycleError
+	switch {
+	case errors.As(err, &cycle):
+		return fmt.Errorf("%w: dependency would create a cycle %s", model.ErrInvalidInput, s.formatPath(ctx, cycle.Path))
+	case errors.Is(err, model.ErrAlreadyExists):
+		return fmt.Errorf("task %s already depends on %s: %w", task.DisplayID(), dependsOn.DisplayID(), model.ErrAlreadyExists)
+	case err != nil:
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Remove(ctx context.Context, taskID, dependsOnID int) error {
+	if err := requirePrincipal(ctx); err != nil {
+		return err
+	}
+
+	if err := s.dependencyRepository.Remove(ctx, taskID, dependsOnID); err != nil {
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Dependencies(ctx context.Context, taskID int) ([]*model.Task, error) {
+	if err := requirePrincipal(ctx); err != nil {
+		return nil, err
+	}
+
+	graph, err := s.dependencyRepository.Graph(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+
+	return s.getTasks(ctx, graph[taskID])
+}
+
+func (s *service) Dependents(ctx context.Context, taskID int) ([]*model.Task, error) {
+	if err := requirePrincipal(ctx); err != nil {
+		return nil, err
+	}
+
+	graph, err := s.dependencyRepository.Graph(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+
+	return s.getTasks(ctx, graph.Dependents(taskID))
+}
+
+func (s *service) Unblocked(ctx context.Context, taskID int) ([]*model.Task, error) {
+	dependents, err := s.Dependents(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	unblocked := []*model.Task{}
+	for _, task := range dependents {
+		if !task.IsWaiting() && !task.Status.IsFinished() {
+			unblocked = append(unblocked, task)
+		}
+	}
+	return unblocked, nil
+}
+
+// getTasks loads tasks by id, ordered by id. Tasks in the trash keep their
+// dependencies for a restore but are left out.
+func (s *service) getTasks(ctx context.Context, ids []int) ([]*model.Task, error) {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+
+	tasks := make([]*model.Task, 0, len(ids))
+	for _, id := range ids {
+		task, err := s.taskRepository.GetByID(ctx, id)
+		if errors.Is(err, model.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// formatPath renders a chain of task ids as "A-1 -> A-2 -> A-1".
+func (s *service) formatPath(ctx context.Context, ids []int) string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		name := fmt.Sprint(id)
+		if task, err := s.taskRepository.GetByID(ctx, id); err == nil {
+			name = task.DisplayID()
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, " -> ")
+}
@@ -0,0 +1,20 @@
+package dependency
+
+import (
+	"techno/internal/repository"
+	def "techno/internal/service"
+)
+
+var _ def.DependencyService = (*service)(nil)
+
+type service struct {
+	dependencyRepository repository.DependencyRepository
+	taskRepository       repository.TaskRepository
+}
+
+func NewService(dependencyRepository repository.DependencyRepository, taskRepository repository.TaskRepository) *service {
+	return &service{
+		dependencyRepository: dependencyRepository,
+		taskRepository:       taskRepository,
+	}
+}
@@ -0,0 +1,80 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/model"
+	"time"
+)
+
+func (s *service) History(ctx context.Context, input string) ([]*model.TaskEvent, error) {
+	ref, err := model.ParseTaskRef(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// A plain id also finds tasks that are deleted or archived, a project
+	// scoped one only points at a task that is still in the list.
+	id := ref.ID
+	if ref.ProjectKey != "" {
+		task, err := s.taskRepository.GetByProjectNumber(ctx, ref.ProjectKey, ref.Number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get task: %w", err)
+		}
+		id = task.ID
+	}
+
+	events, err := s.eventRepository.History(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task history: %w", err)
+	}
+	return events, nil
+}
+
+func (s *service) AsOf(ctx context.Context, at time.Time, filter model.TaskFilter) ([]*model.Task, error) {
+	if at.After(time.Now()) {
+		return nil, fmt.Errorf("%w: as-of time %s is in the future", model.ErrInvalidInput, at.Format(time.RFC3339))
+	}
+	filter, err := filter.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepository.Until(ctx, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task events: %w", err)
+	}
+	replayed, err := model.ReplayEvents(events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild tasks: %w", err)
+	}
+
+	// Like the task list, tasks of archived projects are hidden unless the
+	// project is asked for. Projects keep no archive history, only their
+	// current archived_at is known: a project restored since at counts as
+	// never archived, one archived again counts from its last archiving.
+	archived := make(map[string]bool)
+	if filter.Project == "" {
+		projects, err := s.projectRepository.List(ctx, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+		for _, project := range projects {
+			if project.IsArchived() && !project.ArchivedAt.After(at) {
+				archived[project.Key] = true
+			}
+		}
+	}
+
+	tasks := make([]*model.Task, 0, len(replayed))
+	for _, task := range replayed {
+		if archived[task.ProjectKey] {
+			continue
+		}
+		if filter.Match(task, at) {
+			tasks = append(tasks, task)
+		}
+	}
+	model.SortTasks(tasks, filter.Sort)
+	return tasks, nil
+}
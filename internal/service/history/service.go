@@ -0,0 +1,22 @@
+package history
+
+import (
+	"techno/internal/repository"
+	def "techno/internal/service"
+)
+
+var _ def.HistoryService = (*service)(nil)
+
+type service struct {
+	eventRepository   repository.EventRepository
+	taskRepository    repository.TaskRepository
+	projectRepository repository.ProjectRepository
+}
+
+func NewService(eventRepository repository.EventRepository, taskRepository repository.TaskRepository, projectRepository repository.ProjectRepository) *service {
+	return &service{
+		eventRepository:   eventRepository,
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+	}
+}
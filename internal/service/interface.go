@@ -3,13 +3,113 @@ package service
 import (
 	"context"
 	"techno/internal/model"
+	"time"
 )
 
 type TaskService interface {
 	CreateTask(ctx context.Context, task *model.Task) error
 	GetByID(ctx context.Context, id int) (*model.Task, error)
+	// GetByRef finds a task by a plain id or a project scoped one (INFRA-12).
+	GetByRef(ctx context.Context, ref string) (*model.Task, error)
 	GetAll(ctx context.Context) ([]*model.Task, error)
 	GetByStatus(ctx context.Context, status model.TaskStatus) ([]*model.Task, error)
-	UpdateTask(ctx context.Context, task *model.Task) error
-	DeleteTask(ctx context.Context, id int) error
+	// GetSubtasks returns every descendant of a task, oldest first.
+	GetSubtasks(ctx context.Context, id int) ([]*model.Task, error)
+	List(ctx context.Context, filter model.TaskFilter) ([]*model.Task, error)
+	// Next ranks the unfinished tasks by urgency and returns the top limit.
+	Next(ctx context.Context, limit int) ([]model.RankedTask, error)
+	UpdateTask(ctx context.Context, task *model.Task, opts model.UpdateOptions) error
+	DeleteTask(ctx context.Context, id int, policy model.ChildPolicy) error
+	AllowedTransitions(from model.TaskStatus) []model.TaskStatus
+}
+
+type DependencyService interface {
+	// Add makes taskID wait for dependsOnID, refusing edges that would
+	// close a cycle.
+	Add(ctx context.Context, taskID, dependsOnID int) error
+	Remove(ctx context.Context, taskID, dependsOnID int) error
+	// Dependencies returns the tasks taskID waits for.
+	Dependencies(ctx context.Context, taskID int) ([]*model.Task, error)
+	// Dependents returns the tasks waiting for taskID.
+	Dependents(ctx context.Context, taskID int) ([]*model.Task, error)
+	// Unblocked returns the unfinished dependents of taskID that have
+	// nothing left to wait for.
+	Unblocked(ctx context.Context, taskID int) ([]*model.Task, error)
+}
+
+type ProjectService interface {
+	Create(ctx context.Context, project *model.Project) error
+	List(ctx context.Context, includeArchived bool) ([]*model.Project, error)
+	Archive(ctx context.Context, key string) error
+	Restore(ctx context.Context, key string) error
+}
+
+type RecurrenceService interface {
+	// Create checks the rule and schedules the first occurrence.
+	Create(ctx context.Context, recurrence *model.Recurrence) error
+	List(ctx context.Context) ([]*model.Recurrence, error)
+	Delete(ctx context.Context, id int) error
+	// Materialize creates the next task of every recurrence that is due at
+	// now and returns the tasks it created. Running it again for the same
+	// occurrence does not create a second task.
+	Materialize(ctx context.Context, now time.Time) ([]*model.Task, error)
+}
+
+type ArchiveService interface {
+	// Archive moves a finished task out of the task list into the archive.
+	Archive(ctx context.Context, id int) error
+	List(ctx context.Context) ([]*model.ArchivedTask, error)
+	// Search returns the archived tasks with query in the title or the
+	// description, ignoring case.
+	Search(ctx context.Context, query string) ([]*model.ArchivedTask, error)
+	// Restore puts an archived task back with the id it had.
+	Restore(ctx context.Context, id int) (*model.Task, error)
+}
+
+type TrashService interface {
+	List(ctx context.Context) ([]*model.Task, error)
+	// Restore takes a task out of the trash with the subtasks deleted along
+	// with it, the task comes first.
+	Restore(ctx context.Context, id int) ([]*model.Task, error)
+	// Empty deletes for good the tasks that are in the trash for longer than
+	// olderThan, all of them when it is zero, and returns how many.
+	Empty(ctx context.Context, olderThan time.Duration) (int, error)
+}
+
+type JournalService interface {
+	// Undo reverts up to n of the latest operations of the current user,
+	// latest first, and returns the ones it reverted. It stops with
+	// model.ErrConflict at an operation whose task was changed since.
+	Undo(ctx context.Context, n int) ([]*model.Operation, error)
+	// Redo applies the operation that was undone last again.
+	Redo(ctx context.Context) (*model.Operation, error)
+}
+
+type HistoryService interface {
+	// History returns the changes made to a task, oldest first. The task is
+	// a plain id, which also finds deleted and archived tasks, or a project
+	// scoped one (INFRA-12).
+	History(ctx context.Context, ref string) ([]*model.TaskEvent, error)
+	// AsOf rebuilds the task list as it was at the given time from the
+	// recorded events and applies the filter to it, overdue meaning overdue
+	// at that time. Tasks of projects archived by then are hidden unless the
+	// filter names the project, judged by the current archive state of the
+	// project since its earlier archivings are not kept. Dependencies have no
+	// events, the tasks come without open dependencies.
+	AsOf(ctx context.Context, at time.Time, filter model.TaskFilter) ([]*model.Task, error)
+}
+
+type TagService interface {
+	List(ctx context.Context) ([]*model.Tag, error)
+	Rename(ctx context.Context, from, to string) error
+	Merge(ctx context.Context, sources []string, target string) error
+	Delete(ctx context.Context, name string) error
+}
+
+type AuthService interface {
+	Register(ctx context.Context, login, password string) (*model.User, error)
+	Login(ctx context.Context, login, password string) (string, error)
+	Logout(ctx context.Context, token string) error
+	Authenticate(ctx context.Context, token string) (*model.User, error)
+	SetTimezone(ctx context.Context, timezone string) error
 }
@@ -0,0 +1,126 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"techno/internal/model"
+	"techno/internal/repository"
+)
+
+func (s *service) Undo(ctx context.Context, n int) ([]*model.Operation, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: number of operations must be positive, got %d", model.ErrInvalidInput, n)
+	}
+
+	ops, err := s.journalRepository.Done(ctx, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operations: %w", err)
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("operation to undo %w", model.ErrNotFound)
+	}
+
+	undone := make([]*model.Operation, 0, len(ops))
+	for _, op := range ops {
+		replayCtx := repository.WithReplay(ctx, repository.Replay{OperationID: op.ID, Undo: true})
+		if err := s.revert(replayCtx, op); err != nil {
+			return undone, fmt.Errorf("failed to undo %s of task %d: %w", op.Kind, op.TaskID, err)
+		}
+		undone = append(undone, op)
+	}
+	return undone, nil
+}
+
+func (s *service) Redo(ctx context.Context) (*model.Operation, error) {
+	op, err := s.journalRepository.LastUndone(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operation to redo: %w", err)
+	}
+
+	replayCtx := repository.WithReplay(ctx, repository.Replay{OperationID: op.ID})
+	if err := s.replay(replayCtx, op); err != nil {
+		return nil, fmt.Errorf("failed to redo %s of task %d: %w", op.Kind, op.TaskID, err)
+	}
+	return op, nil
+}
+
+// revert puts the task back to the before-image of the operation. A
+// created task goes to the trash and a deleted one comes back from it
+// together with the subtasks the delete changed. The repository checks
+// that the tasks still look the way the operation left them and marks the
+// operation undone in the transaction of the change.
+func (s *service) revert(ctx context.Context, op *model.Operation) error {
+	switch op.Kind {
+	case model.OperationCreate:
+		return s.delete(ctx, op.TaskID, model.ChildrenRefuse, op.After, nil)
+	case model.OperationUpdate:
+		return s.apply(ctx, op.Before, op.After)
+	case model.OperationDelete:
+		return s.undelete(ctx, op)
+	}
+	return fmt.Errorf("%w: unknown operation %q", model.ErrInvalidInput, op.Kind)
+}
+
+// replay applies the operation again after revert. A delete uses the
+// policy of the original one and has to touch the same subtasks.
+func (s *service) replay(ctx context.Context, op *model.Operation) error {
+	switch op.Kind {
+	case model.OperationCreate:
+		return s.restore(ctx, op.TaskID)
+	case model.OperationUpdate:
+		return s.apply(ctx, op.After, op.Before)
+	case model.OperationDelete:
+		return s.delete(ctx, op.TaskID, op.Policy, op.Before, op.SubtasksBefore())
+	}
+	return fmt.Errorf("%w: unknown operation %q", model.ErrInvalidInput, op.Kind)
+}
+
+// apply writes image over the task if it still looks like expected.
+func (s *service) apply(ctx context.Context, image, expected *model.Task) error {
+	task := *image
+	err := s.taskRepository.UpdateTask(ctx, &task, expected)
+	if errors.Is(err, model.ErrNotFound) {
+		return fmt.Errorf("%w: task %d no longer exists", model.ErrConflict, image.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+	return nil
+}
+
+// delete moves the task to the trash if it and the subtasks the policy
+// touches still look like expected and subtasks.
+func (s *service) delete(ctx context.Context, id int, policy model.ChildPolicy, expected *model.Task, subtasks []*model.Task) error {
+	err := s.taskRepository.DeleteTask(ctx, id, policy, expected, subtasks)
+	if errors.Is(err, model.ErrNotFound) {
+		return fmt.Errorf("%w: task %d no longer exists", model.ErrConflict, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	return nil
+}
+
+// undelete reverts the delete of op with the subtasks it changed.
+func (s *service) undelete(ctx context.Context, op *model.Operation) error {
+	err := s.taskRepository.UndeleteTask(ctx, op)
+	if errors.Is(err, model.ErrNotFound) {
+		return fmt.Errorf("%w: task %d is no longer in the trash", model.ErrConflict, op.TaskID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+	return nil
+}
+
+func (s *service) restore(ctx context.Context, id int) error {
+	_, err := s.trashRepository.Restore(ctx, id)
+	if errors.Is(err, model.ErrNotFound) {
+		return fmt.Errorf("%w: task %d is no longer in the trash", model.ErrConflict, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package journal
+
+import (
+	"techno/internal/repository"
+	def "techno/internal/service"
+)
+
+var _ def.JournalService = (*service)(nil)
+
+// service works on the repositories directly, the checks of the task
+// service were done when the operation was made.
+type service struct {
+	journalRepository repository.JournalRepository
+	taskRepository    repository.TaskRepository
+	trashRepository   repository.TrashRepository
+}
+
+func NewService(
+	journalRepository repository.JournalRepository,
+	taskRepository repository.TaskRepository,
+	trashRepository repository.TrashRepository,
+) *service {
+	return &service{
+		journalRepository: journalRepository,
+		taskRepository:    taskRepository,
+		trashRepository:   trashRepository,
+	}
+}
@@ -0,0 +1,73 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"techno/internal/model"
+)
+
+func (s *service) Create(ctx context.Context, project *model.Project) error {
+	key, err := model.NormalizeProjectKey(project.Key)
+	if err != nil {
+		return err
+	}
+	project.Key = key
+	project.Name = strings.TrimSpace(project.Name)
+	project.Description = strings.TrimSpace(project.Description)
+	if project.Name == "" {
+		return fmt.Errorf("%w: project name is required", model.ErrInvalidInput)
+	}
+
+	if err := s.projectRepository.CreateProject(ctx, project); err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) List(ctx context.Context, includeArchived bool) ([]*model.Project, error) {
+	projects, err := s.projectRepository.List(ctx, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	if projects == nil {
+		return []*model.Project{}, nil
+	}
+
+	return projects, nil
+}
+
+func (s *service) Archive(ctx context.Context, key string) error {
+	return s.setArchived(ctx, key, true)
+}
+
+func (s *service) Restore(ctx context.Context, key string) error {
+	return s.setArchived(ctx, key, false)
+}
+
+func (s *service) setArchived(ctx context.Context, key string, archived bool) error {
+	key, err := model.NormalizeProjectKey(key)
+	if err != nil {
+		return err
+	}
+
+	project, err := s.projectRepository.GetByKey(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if project.IsArchived() == archived {
+		state := "active"
+		if archived {
+			state = "archived"
+		}
+		return fmt.Errorf("%w: project %s is already %s", model.ErrInvalidInput, key, state)
+	}
+
+	if err := s.projectRepository.SetArchived(ctx, key, archived); err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package project
+
+import (
+	"techno/internal/repository"
+	def "techno/internal/service"
+)
+
+var _ def.ProjectService = (*service)(nil)
+
+type service struct {
+	projectRepository repository.ProjectRepository
+}
+
+func NewService(projectRepository repository.ProjectRepository) *service {
+	return &service{
+		projectRepository: projectRepository,
+	}
+}
@@ -0,0 +1,159 @@
+package recurrence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"techno/internal/model"
+	"techno/internal/recurrence"
+	"time"
+)
+
+func (s *service) Create(ctx context.Context, rec *model.Recurrence) error {
+	rec.Title = strings.TrimSpace(rec.Title)
+	rec.Description = strings.TrimSpace(rec.Description)
+	if rec.Title == "" {
+		return fmt.Errorf("%w: title is required", model.ErrInvalidInput)
+	}
+	if rec.Priority == 0 {
+		rec.Priority = model.PriorityMedium
+	}
+	if !rec.Priority.Valid() {
+		return fmt.Errorf("%w: invalid task priority %d", model.ErrInvalidInput, rec.Priority)
+	}
+	if rec.DueIn < 0 {
+		return fmt.Errorf("%w: due offset cannot be negative", model.ErrInvalidInput)
+	}
+	tags, err := model.NormalizeTags(rec.Tags)
+	if err != nil {
+		return err
+	}
+	rec.Tags = tags
+
+	if rec.Timezone == "" {
+		rec.Timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(rec.Timezone)
+	if err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", model.ErrInvalidInput, rec.Timezone)
+	}
+	schedule, err := recurrence.Parse(rec.Rule)
+	if err != nil {
+		return err
+	}
+	rec.Rule = strings.TrimSpace(rec.Rule)
+	rec.NextAt = schedule.Next(time.Now().In(loc))
+	if rec.NextAt.IsZero() {
+		return fmt.Errorf("%w: rule %q never fires", model.ErrInvalidInput, rec.Rule)
+	}
+
+	if rec.ProjectKey != "" {
+		key, err := model.NormalizeProjectKey(rec.ProjectKey)
+		if err != nil {
+			return err
+		}
+		project, err := s.projectRepository.GetByKey(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to get project: %w", err)
+		}
+		if project.IsArchived() {
+			return fmt.Errorf("%w: project %s is archived", model.ErrInvalidInput, key)
+		}
+		rec.ProjectID = &project.ID
+		rec.ProjectKey = key
+	}
+
+	if err := s.recurrenceRepository.CreateRecurrence(ctx, rec); err != nil {
+		return fmt.Errorf("failed to create recurrence: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) List(ctx context.Context) ([]*model.Recurrence, error) {
+	recurrences, err := s.recurrenceRepository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurrences: %w", err)
+	}
+
+	if recurrences == nil {
+		return []*model.Recurrence{}, nil
+	}
+
+	return recurrences, nil
+}
+
+func (s *service) Delete(ctx context.Context, id int) error {
+	if id <= 0 {
+		return fmt.Errorf("%w: invalid recurrence id %d", model.ErrInvalidInput, id)
+	}
+
+	if err := s.recurrenceRepository.DeleteRecurrence(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete recurrence: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Materialize(ctx context.Context, now time.Time) ([]*model.Task, error) {
+	due, err := s.recurrenceRepository.Due(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due recurrences: %w", err)
+	}
+
+	var created []*model.Task
+	var errs []error
+	for _, rec := range due {
+		task, err := s.materialize(ctx, rec, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recurrence %d: %w", rec.ID, err))
+			continue
+		}
+		if task != nil {
+			created = append(created, task)
+		}
+	}
+
+	return created, errors.Join(errs...)
+}
+
+// materialize creates the task for rec.NextAt and moves NextAt to the
+// first occurrence after now. Occurrences missed while the worker was down
+// are skipped rather than created in a burst.
+//
+// The task is created before NextAt moves. A run that stops in between
+// finds the task already there on the next try, which the unique
+// occurrence index reports as ErrAlreadyExists, and only moves NextAt.
+func (s *service) materialize(ctx context.Context, rec *model.Recurrence, now time.Time) (*model.Task, error) {
+	schedule, err := recurrence.Parse(rec.Rule)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(rec.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: %w", rec.Timezone, err)
+	}
+
+	task := rec.Occurrence(rec.NextAt)
+	if err := s.taskRepository.CreateTask(ctx, task); err != nil {
+		if !errors.Is(err, model.ErrAlreadyExists) {
+			return nil, fmt.Errorf("failed to create task: %w", err)
+		}
+		task = nil
+	}
+
+	next := schedule.Next(rec.NextAt.In(loc))
+	for !next.IsZero() && !next.After(now) {
+		next = schedule.Next(next)
+	}
+	if next.IsZero() {
+		return task, fmt.Errorf("rule %q has no more occurrences", rec.Rule)
+	}
+
+	if _, err := s.recurrenceRepository.Advance(ctx, rec.ID, rec.NextAt, next); err != nil {
+		return task, fmt.Errorf("failed to schedule next occurrence: %w", err)
+	}
+
+	return task, nil
+}
@@ -0,0 +1,26 @@
+package recurrence
+
+import (
+	"techno/internal/repository"
+	def "techno/internal/service"
+)
+
+var _ def.RecurrenceService = (*service)(nil)
+
+type service struct {
+	recurrenceRepository repository.RecurrenceRepository
+	taskRepository       repository.TaskRepository
+	projectRepository    repository.ProjectRepository
+}
+
+func NewService(
+	recurrenceRepository repository.RecurrenceRepository,
+	taskRepository repository.TaskRepository,
+	projectRepository repository.ProjectRepository,
+) *service {
+	return &service{
+		recurrenceRepository: recurrenceRepository,
+		taskRepository:       taskRepository,
+		projectRepository:    projectRepository,
+	}
+}
@@ -0,0 +1,73 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/model"
+)
+
+func (s *service) List(ctx context.Context) ([]*model.Tag, error) {
+	tags, err := s.tagRepository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	if tags == nil {
+		return []*model.Tag{}, nil
+	}
+
+	return tags, nil
+}
+
+func (s *service) Rename(ctx context.Context, from, to string) error {
+	from, err := model.NormalizeTag(from)
+	if err != nil {
+		return err
+	}
+	to, err = model.NormalizeTag(to)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return fmt.Errorf("%w: tag is already called %q", model.ErrInvalidInput, to)
+	}
+
+	if err := s.tagRepository.Rename(ctx, from, to); err != nil {
+		return fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Merge(ctx context.Context, sources []string, target string) error {
+	target, err := model.NormalizeTag(target)
+	if err != nil {
+		return err
+	}
+	sources, err = model.NormalizeTags(sources)
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("%w: at least one tag to merge is required", model.ErrInvalidInput)
+	}
+
+	if err := s.tagRepository.Merge(ctx, sources, target); err != nil {
+		return fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) Delete(ctx context.Context, name string) error {
+	name, err := model.NormalizeTag(name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tagRepository.Delete(ctx, name); err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package tag
+
+import (
+	"techno/internal/repository"
+	def "techno/internal/service"
+)
+
+var _ def.TagService = (*service)(nil)
+
+type service struct {
+	tagRepository repository.TagRepository
+}
+
+func NewService(tagRepository repository.TagRepository) *service {
+	return &service{
+		tagRepository: tagRepository,
+	}
+}
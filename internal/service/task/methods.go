@@ -4,12 +4,45 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"techno/internal/auth"
 	"techno/internal/model"
+	"time"
 )
 
+func requirePrincipal(ctx context.Context) error {
+	if _, ok := auth.FromContext(ctx); !ok {
+		return model.ErrUnauthenticated
+	}
+	return nil
+}
+
 func (s *service) CreateTask(ctx context.Context, task *model.Task) error {
+	if err := requirePrincipal(ctx); err != nil {
+		return err
+	}
+
 	task.Title = strings.TrimSpace(task.Title)
 	task.Description = strings.TrimSpace(task.Description)
+	if task.Title == "" {
+		return fmt.Errorf("%w: title is required", model.ErrInvalidInput)
+	}
+	if task.Priority == 0 {
+		task.Priority = model.PriorityMedium
+	}
+	if !task.Priority.Valid() {
+		return fmt.Errorf("%w: invalid task priority %d", model.ErrInvalidInput, task.Priority)
+	}
+	tags, err := model.NormalizeTags(task.Tags)
+	if err != nil {
+		return err
+	}
+	task.Tags = tags
+	if err := s.resolveProject(ctx, task, nil); err != nil {
+		return err
+	}
+	if err := s.checkParent(ctx, task); err != nil {
+		return err
+	}
 
 	if err := s.taskRepository.CreateTask(ctx, task); err != nil {
 		return fmt.Errorf("failed to create task: %w", err)
@@ -19,8 +52,12 @@ func (s *service) CreateTask(ctx context.Context, task *model.Task) error {
 }
 
 func (s *service) GetByID(ctx context.Context, id int) (*model.Task, error) {
+	if err := requirePrincipal(ctx); err != nil {
+		return nil, err
+	}
+
 	if id <= 0 {
-		return nil, fmt.Errorf("invalid task id: %d", id)
+		return nil, fmt.Errorf("%w: invalid task id %d", model.ErrInvalidInput, id)
 	}
 
 	task, err := s.taskRepository.GetByID(ctx, id)
@@ -31,7 +68,49 @@ func (s *service) GetByID(ctx context.Context, id int) (*model.Task, error) {
 	return task, nil
 }
 
+func (s *service) GetByRef(ctx context.Context, input string) (*model.Task, error) {
+	if err := requirePrincipal(ctx); err != nil {
+		return nil, err
+	}
+
+	ref, err := model.ParseTaskRef(input)
+	if err != nil {
+		return nil, err
+	}
+	if ref.ProjectKey == "" {
+		return s.GetByID(ctx, ref.ID)
+	}
+
+	task, err := s.taskRepository.GetByProjectNumber(ctx, ref.ProjectKey, ref.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return task, nil
+}
+
+func (s *service) GetSubtasks(ctx context.Context, id int) ([]*model.Task, error) {
+	if err := requirePrincipal(ctx); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepository.GetSubtasks(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	if tasks == nil {
+		return []*model.Task{}, nil
+	}
+
+	return tasks, nil
+}
+
 func (s *service) GetAll(ctx context.Context) ([]*model.Task, error) {
+	if err := requirePrincipal(ctx); err != nil {
+		return nil, err
+	}
+
 	tasks, err := s.taskRepository.GetAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all tasks: %w", err)
@@ -45,6 +124,10 @@ func (s *service) GetAll(ctx context.Context) ([]*model.Task, error) {
 }
 
 func (s *service) GetByStatus(ctx context.Context, status model.TaskStatus) ([]*model.Task, error) {
+	if err := requirePrincipal(ctx); err != nil {
+		return nil, err
+	}
+
 	tasks, err := s.taskRepository.GetByStatus(ctx, status)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tasks by status: %w", err)
@@ -57,9 +140,34 @@ func (s *service) GetByStatus(ctx context.Context, status model.TaskStatus) ([]*
 	return tasks, nil
 }
 
-func (s *service) UpdateTask(ctx context.Context, task *model.Task) error {
+func (s *service) Next(ctx context.Context, limit int) ([]model.RankedTask, error) {
+	if err := requirePrincipal(ctx); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: limit must be positive, got %d", model.ErrInvalidInput, limit)
+	}
+
+	tasks, err := s.taskRepository.List(ctx, model.TaskFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	ranked := s.urgency.Rank(tasks, time.Now())
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked, nil
+}
+
+func (s *service) UpdateTask(ctx context.Context, task *model.Task, opts model.UpdateOptions) error {
+	if err := requirePrincipal(ctx); err != nil {
+		return err
+	}
+
 	if task.ID <= 0 {
-		return fmt.Errorf("invalid task id: %d", task.ID)
+		return fmt.Errorf("%w: invalid task id %d", model.ErrInvalidInput, task.ID)
 	}
 
 	existingTask, err := s.taskRepository.GetByID(ctx, task.ID)
@@ -69,19 +177,54 @@ func (s *service) UpdateTask(ctx context.Context, task *model.Task) error {
 
 	task.Title = strings.TrimSpace(task.Title)
 	task.Description = strings.TrimSpace(task.Description)
+	if task.Title == "" {
+		return fmt.Errorf("%w: title is required", model.ErrInvalidInput)
+	}
 
+	if err := checkTransition(existingTask.Status, task.Status); err != nil {
+		return err
+	}
+	if task.Priority == 0 {
+		task.Priority = existingTask.Priority
+	}
+	if !task.Priority.Valid() {
+		return fmt.Errorf("%w: invalid task priority %d", model.ErrInvalidInput, task.Priority)
+	}
+	if task.Tags, err = model.NormalizeTags(task.Tags); err != nil {
+		return err
+	}
+	if err := s.resolveProject(ctx, task, existingTask); err != nil {
+		return err
+	}
+	if !sameParent(task.ParentID, existingTask.ParentID) {
+		if err := s.checkParent(ctx, task); err != nil {
+			return err
+		}
+	}
+	if task.Status.IsFinished() && !existingTask.Status.IsFinished() && !opts.Force {
+		if err := s.checkSubtasksFinished(ctx, task); err != nil {
+			return err
+		}
+	}
+
+	task.OwnerID = existingTask.OwnerID
 	task.CreatedAt = existingTask.CreatedAt
 
-	if err := s.taskRepository.UpdateTask(ctx, task); err != nil {
+	// The checks above hold only while the task is still existingTask.
+	if err := s.taskRepository.UpdateTask(ctx, task, existingTask); err != nil {
 		return fmt.Errorf("failed to update task: %w", err)
 	}
 
 	return nil
 }
 
-func (s *service) DeleteTask(ctx context.Context, id int) error {
+func (s *service) DeleteTask(ctx context.Context, id int, policy model.ChildPolicy) error {
+	if err := requirePrincipal(ctx); err != nil {
+		return err
+	}
+
 	if id <= 0 {
-		return fmt.Errorf("invalid task id: %d", id)
+		return fmt.Errorf("%w: invalid task id %d", model.ErrInvalidInput, id)
 	}
 
 	_, err := s.taskRepository.GetByID(ctx, id)
@@ -89,9 +232,120 @@ func (s *service) DeleteTask(ctx context.Context, id int) error {
 		return fmt.Errorf("task not found: %w", err)
 	}
 
-	if err := s.taskRepository.DeleteTask(ctx, id); err != nil {
+	if err := s.taskRepository.DeleteTask(ctx, id, policy, nil, nil); err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
 
 	return nil
 }
+
+func (s *service) List(ctx context.Context, filter model.TaskFilter) ([]*model.Task, error) {
+	if err := requirePrincipal(ctx); err != nil {
+		return nil, err
+	}
+
+	filter, err := filter.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepository.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	if tasks == nil {
+		return []*model.Task{}, nil
+	}
+
+	return tasks, nil
+}
+
+// resolveProject turns task.ProjectKey into task.ProjectID. An empty key
+// takes the task out of its project. Tasks can stay in a project that was
+// archived after they joined it but cannot be moved into one.
+func (s *service) resolveProject(ctx context.Context, task *model.Task, existing *model.Task) error {
+	if task.ProjectKey == "" {
+		task.ProjectID = nil
+		return nil
+	}
+
+	key, err := model.NormalizeProjectKey(task.ProjectKey)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ProjectID != nil && existing.ProjectKey == key {
+		task.ProjectID = existing.ProjectID
+		task.ProjectKey = key
+		return nil
+	}
+
+	project, err := s.projectRepository.GetByKey(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if project.IsArchived() {
+		return fmt.Errorf("%w: project %s is archived", model.ErrInvalidInput, key)
+	}
+
+	task.ProjectID = &project.ID
+	task.ProjectKey = key
+	return nil
+}
+
+// checkParent makes sure task.ParentID points at a task of the same owner
+// and does not make the task its own ancestor.
+func (s *service) checkParent(ctx context.Context, task *model.Task) error {
+	if task.ParentID == nil {
+		return nil
+	}
+	if *task.ParentID == task.ID {
+		return fmt.Errorf("%w: task cannot be its own parent", model.ErrInvalidInput)
+	}
+
+	parent, err := s.taskRepository.GetByID(ctx, *task.ParentID)
+	if err != nil {
+		return fmt.Errorf("parent task not found: %w", err)
+	}
+	if parent.OwnerID != task.OwnerID && task.OwnerID != 0 {
+		return fmt.Errorf("parent task with id %d %w", parent.ID, model.ErrNotFound)
+	}
+
+	for ancestor := parent; ancestor.ParentID != nil; {
+		if task.ID != 0 && *ancestor.ParentID == task.ID {
+			return fmt.Errorf("%w: task %d is a subtask of %d, it cannot become its parent", model.ErrInvalidInput, parent.ID, task.ID)
+		}
+		if ancestor, err = s.taskRepository.GetByID(ctx, *ancestor.ParentID); err != nil {
+			return fmt.Errorf("failed to get parent task: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkSubtasksFinished refuses to finish a task while any of its subtasks
+// is still open.
+func (s *service) checkSubtasksFinished(ctx context.Context, task *model.Task) error {
+	subtasks, err := s.taskRepository.GetSubtasks(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	var open []string
+	for _, sub := range subtasks {
+		if !sub.Status.IsFinished() {
+			open = append(open, sub.DisplayID())
+		}
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("%w: task %s has open subtasks %s, finish them first or force it",
+			model.ErrInvalidTransition, task.DisplayID(), strings.Join(open, ", "))
+	}
+	return nil
+}
+
+func sameParent(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -1,17 +1,23 @@
 package task
 
-import ("techno/internal/repository"
+import (
+	"techno/internal/model"
+	"techno/internal/repository"
 	def "techno/internal/service"
 )
 
 var _ def.TaskService = (*service)(nil)
 
 type service struct {
-	taskRepository repository.TaskRepository
+	taskRepository    repository.TaskRepository
+	projectRepository repository.ProjectRepository
+	urgency           model.UrgencyWeights
 }
 
-func NewService(taskRepository repository.TaskRepository) *service {
+func NewService(taskRepository repository.TaskRepository, projectRepository repository.ProjectRepository, urgency model.UrgencyWeights) *service {
 	return &service{
-		taskRepository: taskRepository,
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		urgency:           urgency,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,45 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"techno/internal/model"
+)
+
+// transitions lists the statuses a task may move to from each status.
+// Finished tasks can only be reopened.
+var transitions = map[model.TaskStatus][]model.TaskStatus{
+	model.Open:       {model.InProgress, model.Blocked, model.Closed, model.Cancelled},
+	model.InProgress: {model.Open, model.Blocked, model.Closed, model.Cancelled},
+	model.Blocked:    {model.Open, model.InProgress, model.Cancelled},
+	model.Closed:     {model.Open},
+	model.Cancelled:  {model.Open},
+}
+
+func (s *service) AllowedTransitions(from model.TaskStatus) []model.TaskStatus {
+	allowed := transitions[from]
+	return append([]model.TaskStatus(nil), allowed...)
+}
+
+func checkTransition(from, to model.TaskStatus) error {
+	if from == to {
+		return nil
+	}
+
+	for _, status := range transitions[from] {
+		if status == to {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: cannot move task from %q to %q, allowed: %s",
+		model.ErrInvalidTransition, from.StringStatus(), to.StringStatus(), formatStatuses(transitions[from]))
+}
+
+func formatStatuses(statuses []model.TaskStatus) string {
+	names := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		names = append(names, status.StringStatus())
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,44 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"techno/internal/model"
+	"time"
+)
+
+func (s *service) List(ctx context.Context) ([]*model.Task, error) {
+	tasks, err := s.trashRepository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted tasks: %w", err)
+	}
+
+	if tasks == nil {
+		return []*model.Task{}, nil
+	}
+	return tasks, nil
+}
+
+func (s *service) Restore(ctx context.Context, id int) ([]*model.Task, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("%w: invalid task id %d", model.ErrInvalidInput, id)
+	}
+
+	tasks, err := s.trashRepository.Restore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+	return tasks, nil
+}
+
+func (s *service) Empty(ctx context.Context, olderThan time.Duration) (int, error) {
+	if olderThan < 0 {
+		return 0, fmt.Errorf("%w: age cannot be negative", model.ErrInvalidInput)
+	}
+
+	purged, err := s.trashRepository.Purge(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("failed to empty trash: %w", err)
+	}
+	return purged, nil
+}
@@ -0,0 +1,18 @@
+package trash
+
+import (
+	"techno/internal/repository"
+	def "techno/internal/service"
+)
+
+var _ def.TrashService = (*service)(nil)
+
+type service struct {
+	trashRepository repository.TrashRepository
+}
+
+func NewService(trashRepository repository.TrashRepository) *service {
+	return &service{
+		trashRepository: trashRepository,
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"techno/internal/auth"
+	"techno/internal/config/cleaner"
 	"techno/internal/config/logger"
 	"techno/internal/model"
 	"techno/internal/service"
@@ -13,35 +15,60 @@ import (
 )
 
 type TaskCleaner struct {
-	taskService service.TaskService
-	interval    time.Duration
-	stopChan    chan struct{}
-	log         zerolog.Logger
+	taskService    service.TaskService
+	archiveService service.ArchiveService
+	trashService   service.TrashService
+	interval       time.Duration
+	mode           cleaner.Mode
+	policy         model.RetentionPolicy
+	trashRetention time.Duration
+	stopChan       chan struct{}
+	log            zerolog.Logger
 }
 
-func NewTaskCleaner(taskService service.TaskService, interval time.Duration) *TaskCleaner {
+// NewTaskCleaner archives or, in delete mode, deletes done tasks once the
+// policy says they are kept long enough and empties the trash of tasks
+// deleted more than trashRetention ago, checking every interval.
+func NewTaskCleaner(
+	taskService service.TaskService,
+	archiveService service.ArchiveService,
+	trashService service.TrashService,
+	interval time.Duration,
+	mode cleaner.Mode,
+	policy model.RetentionPolicy,
+	trashRetention time.Duration,
+) *TaskCleaner {
 	return &TaskCleaner{
-		taskService: taskService,
-		interval:    interval,
-		stopChan:    make(chan struct{}),
-		log:         logger.GetLogger("timer.task_cleaner"),
+		taskService:    taskService,
+		archiveService: archiveService,
+		trashService:   trashService,
+		interval:       interval,
+		mode:           mode,
+		policy:         policy,
+		trashRetention: trashRetention,
+		stopChan:       make(chan struct{}),
+		log:            logger.GetLogger("timer.task_cleaner"),
 	}
 }
 
 func (tc *TaskCleaner) Start(ctx context.Context) {
+	ctx = auth.WithPrincipal(ctx, auth.System())
+
 	ticker := time.NewTicker(tc.interval)
 	defer ticker.Stop()
 
-	log.Printf("Task cleaner started (interval: %v)", tc.interval)
+	log.Printf("Task cleaner started (interval: %v, mode: %s)", tc.interval, tc.mode)
 
 	tc.cleanCompletedTasks(ctx)
+	tc.purgeTrash(ctx)
 
 	for {
 		select {
 		case <-ticker.C:
 			tc.log.Debug().Msg("task cleaner tick")
-			cleanCtx := context.Background()
+			cleanCtx := auth.WithPrincipal(context.Background(), auth.System())
 			tc.cleanCompletedTasks(cleanCtx)
+			tc.purgeTrash(cleanCtx)
 
 		case <-tc.stopChan:
 			tc.log.Info().Msg("task cleaner stopped by Stop()")
@@ -60,7 +87,7 @@ func (tc *TaskCleaner) Stop() {
 
 func (tc *TaskCleaner) cleanCompletedTasks(ctx context.Context) {
 	start := time.Now()
-	tasks, err := tc.taskService.GetByStatus(ctx, model.Closed)
+	completed, err := tc.taskService.GetByStatus(ctx, model.Closed)
 	if err != nil {
 		tc.log.Error().
 			Err(err).
@@ -69,8 +96,16 @@ func (tc *TaskCleaner) cleanCompletedTasks(ctx context.Context) {
 		return
 	}
 
+	var tasks []*model.Task
+	for _, task := range completed {
+		if tc.policy.Expired(task, start) {
+			tasks = append(tasks, task)
+		}
+	}
+
 	tc.log.Info().
-		Int("count", len(tasks)).
+		Int("completed", len(completed)).
+		Int("expired", len(tasks)).
 		Dur("duration", time.Since(start)).
 		Msg("found completed tasks")
 
@@ -86,25 +121,70 @@ func (tc *TaskCleaner) cleanCompletedTasks(ctx context.Context) {
 		if len(title) > 50 {
 			title = title[:47] + "..."
 		}
-		fmt.Printf("%-5d %-50s %-20s\n", task.ID, title, task.CreatedAt.Format("2006-01-02 15:04:05"))
+		completedAt := "-"
+		if task.CompletedAt != nil {
+			completedAt = task.CompletedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%-5d %-50s %-20s\n", task.ID, title, completedAt)
 	}
 
-	deletedCount := 0
+	cleanedCount := 0
 	for _, task := range tasks {
-		if err := tc.taskService.DeleteTask(ctx, task.ID); err != nil {
+		if err := tc.clean(ctx, task); err != nil {
 			tc.log.Error().
 				Err(err).
 				Int("task_id", task.ID).
-				Msg("Failed to delete task")
+				Str("mode", string(tc.mode)).
+				Msg("Failed to clean task")
 			continue
 		}
-		deletedCount++
+		cleanedCount++
 
 		tc.log.Info().
 			Int("task_id", task.ID).
 			Str("title", task.Title).
-			Msg("task deleted successfull")
+			Str("mode", string(tc.mode)).
+			Msg("task cleaned successfull")
+	}
+
+	if tc.mode == cleaner.ModeDelete {
+		log.Printf("Successfull moved %d completed task(s) to trash\n", cleanedCount)
+		return
+	}
+	log.Printf("Successfull archived %d completed task(s)\n", cleanedCount)
+}
+
+// clean removes one expired task from the task list. Subtasks of a removed
+// task move up to its parent either way.
+func (tc *TaskCleaner) clean(ctx context.Context, task *model.Task) error {
+	if tc.mode == cleaner.ModeDelete {
+		return tc.taskService.DeleteTask(ctx, task.ID, model.ChildrenReparent)
+	}
+	return tc.archiveService.Archive(ctx, task.ID)
+}
+
+// purgeTrash deletes for good the tasks that are in the trash for longer
+// than the trash retention.
+func (tc *TaskCleaner) purgeTrash(ctx context.Context) {
+	if tc.trashRetention == model.KeepForever {
+		return
 	}
 
-	log.Printf("Successfull deleted %d completed task(s)\n", deletedCount)
+	start := time.Now()
+	purged, err := tc.trashService.Empty(ctx, tc.trashRetention)
+	if err != nil {
+		tc.log.Error().
+			Err(err).
+			Dur("duration", time.Since(start)).
+			Msg("Failed to empty trash")
+		return
+	}
+
+	tc.log.Info().
+		Int("purged", purged).
+		Dur("duration", time.Since(start)).
+		Msg("trash purged")
+	if purged > 0 {
+		log.Printf("Purged %d task(s) from the trash\n", purged)
+	}
 }
@@ -0,0 +1,85 @@
+package timer
+
+import (
+	"context"
+	"techno/internal/auth"
+	"techno/internal/config/logger"
+	"techno/internal/service"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// RecurrenceScheduler creates the tasks of recurring templates when the
+// previous occurrence is finished or the next one is scheduled to start.
+type RecurrenceScheduler struct {
+	recurrenceService service.RecurrenceService
+	interval          time.Duration
+	stopChan          chan struct{}
+	log               zerolog.Logger
+}
+
+func NewRecurrenceScheduler(recurrenceService service.RecurrenceService, interval time.Duration) *RecurrenceScheduler {
+	return &RecurrenceScheduler{
+		recurrenceService: recurrenceService,
+		interval:          interval,
+		stopChan:          make(chan struct{}),
+		log:               logger.GetLogger("timer.recurrence_scheduler"),
+	}
+}
+
+func (rs *RecurrenceScheduler) Start(ctx context.Context) {
+	ctx = auth.WithPrincipal(ctx, auth.System())
+
+	ticker := time.NewTicker(rs.interval)
+	defer ticker.Stop()
+
+	rs.log.Info().Dur("interval", rs.interval).Msg("recurrence scheduler started")
+
+	rs.materialize(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			rs.log.Debug().Msg("recurrence scheduler tick")
+			rs.materialize(ctx)
+
+		case <-rs.stopChan:
+			rs.log.Info().Msg("recurrence scheduler stopped by Stop()")
+			return
+
+		case <-ctx.Done():
+			rs.log.Info().Msg("recurrence scheduler stopped by context")
+			return
+		}
+	}
+}
+
+func (rs *RecurrenceScheduler) Stop() {
+	close(rs.stopChan)
+}
+
+func (rs *RecurrenceScheduler) materialize(ctx context.Context) {
+	start := time.Now()
+	tasks, err := rs.recurrenceService.Materialize(ctx, start)
+	if err != nil {
+		rs.log.Error().
+			Err(err).
+			Dur("duration", time.Since(start)).
+			Msg("Failed to materialize some recurrences")
+	}
+
+	for _, task := range tasks {
+		rs.log.Info().
+			Int("task_id", task.ID).
+			Int("recurrence_id", *task.RecurrenceID).
+			Time("occurrence_at", *task.OccurrenceAt).
+			Str("title", task.Title).
+			Msg("recurring task created")
+	}
+
+	rs.log.Debug().
+		Int("count", len(tasks)).
+		Dur("duration", time.Since(start)).
+		Msg("materialized recurrences")
+}
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Tasks created before accounts existed keep a NULL owner and are only
+-- reachable by the system principal (the task cleaner).
+ALTER TABLE tasks ADD COLUMN owner_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_id ON tasks(owner_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_owner_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS owner_id;
+-- +goose StatementEnd
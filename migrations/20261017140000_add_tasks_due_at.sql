@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN due_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_at ON tasks(due_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_due_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_at;
+-- +goose StatementEnd
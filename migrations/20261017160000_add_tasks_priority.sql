@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN priority SMALLINT NOT NULL DEFAULT 2 CHECK (priority BETWEEN 1 AND 4);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_priority ON tasks(owner_id, priority DESC, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_owner_priority;
+ALTER TABLE tasks DROP COLUMN IF EXISTS priority;
+-- +goose StatementEnd
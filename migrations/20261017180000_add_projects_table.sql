@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE projects (
+    id INTEGER PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    last_number INTEGER NOT NULL DEFAULT 0,
+    archived_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (owner_id, key)
+);
+
+ALTER TABLE tasks ADD COLUMN project_id INTEGER REFERENCES projects(id);
+ALTER TABLE tasks ADD COLUMN project_number INTEGER;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_project_number ON tasks(project_id, project_number);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_project_number;
+ALTER TABLE tasks DROP COLUMN IF EXISTS project_number;
+ALTER TABLE tasks DROP COLUMN IF EXISTS project_id;
+DROP TABLE IF EXISTS projects;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE recurrences (
+    id INTEGER PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rule VARCHAR(255) NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    priority SMALLINT NOT NULL DEFAULT 2 CHECK (priority BETWEEN 1 AND 4),
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL,
+    due_in_seconds BIGINT NOT NULL DEFAULT 0,
+    next_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurrences_next_at ON recurrences(next_at);
+
+ALTER TABLE tasks ADD COLUMN recurrence_id INTEGER REFERENCES recurrences(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN occurrence_at TIMESTAMPTZ;
+
+-- One task per occurrence, so a worker that retries after a crash cannot
+-- create an occurrence twice.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_recurrence_occurrence ON tasks(recurrence_id, occurrence_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_recurrence_occurrence;
+ALTER TABLE tasks DROP COLUMN IF EXISTS occurrence_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence_id;
+DROP TABLE IF EXISTS recurrences;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN updated_at TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN completed_at TIMESTAMPTZ;
+
+UPDATE tasks SET updated_at = created_at;
+-- When existing tasks were finished is unknown. Counting from the migration
+-- keeps retention rules from purging all of them at once. Statuses 1 and 4
+-- are closed and cancelled.
+UPDATE tasks SET completed_at = CURRENT_TIMESTAMP WHERE status IN (1, 4);
+
+ALTER TABLE tasks ALTER COLUMN updated_at SET DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE tasks ALTER COLUMN updated_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_completed_at ON tasks(completed_at) WHERE completed_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_completed_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS updated_at;
+-- +goose StatementEnd
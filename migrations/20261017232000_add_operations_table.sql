@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Journal of task changes made through the task service, used by undo and
+-- redo. task_id has no foreign key, the task may be purged from the trash.
+CREATE TABLE operations (
+    id INTEGER PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,
+    task_id INTEGER NOT NULL,
+    before_image JSONB,
+    after_image JSONB,
+    undone_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_operations_user_id ON operations(user_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS operations;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A delete changes subtasks too: child_policy is the policy it used and
+-- subtask_images holds the images of every subtask it took to the trash or
+-- moved to the parent, so undo and redo touch exactly that set. Deletes
+-- journaled before keep an empty list and are undone and redone without
+-- their subtasks.
+ALTER TABLE operations ADD COLUMN child_policy SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE operations ADD COLUMN subtask_images JSONB NOT NULL DEFAULT '[]';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE operations DROP COLUMN IF EXISTS subtask_images;
+ALTER TABLE operations DROP COLUMN IF EXISTS child_policy;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+// Package migrations embeds the SQL migrations so the binaries can apply
+// them without the goose CLI.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed *.sql
+var postgresFS embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFS embed.FS
+
+func Postgres() fs.FS {
+	return postgresFS
+}
+
+func SQLite() fs.FS {
+	sub, err := fs.Sub(sqliteFS, "sqlite")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
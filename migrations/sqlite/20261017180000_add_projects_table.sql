@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE projects (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    last_number INTEGER NOT NULL DEFAULT 0,
+    archived_at TEXT,
+    created_at TEXT NOT NULL,
+    UNIQUE (owner_id, key)
+);
+
+-- No foreign key on project_id: SQLite cannot drop a column that takes
+-- part in one, which would break the down migration.
+ALTER TABLE tasks ADD COLUMN project_id INTEGER;
+ALTER TABLE tasks ADD COLUMN project_number INTEGER;
+
+CREATE UNIQUE INDEX idx_tasks_project_number ON tasks(project_id, project_number);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_project_number;
+ALTER TABLE tasks DROP COLUMN project_number;
+ALTER TABLE tasks DROP COLUMN project_id;
+DROP TABLE IF EXISTS projects;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- No foreign key on parent_id for the same reason as project_id.
+ALTER TABLE tasks ADD COLUMN parent_id INTEGER;
+
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+ALTER TABLE tasks DROP COLUMN parent_id;
+-- +goose StatementEnd
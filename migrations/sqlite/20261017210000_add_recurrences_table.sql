@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE recurrences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rule TEXT NOT NULL,
+    timezone TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    priority INTEGER NOT NULL DEFAULT 2 CHECK (priority BETWEEN 1 AND 4),
+    -- Comma separated, tag names never contain commas. NULL for none.
+    tags TEXT,
+    project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL,
+    due_in_seconds INTEGER NOT NULL DEFAULT 0,
+    next_at TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_recurrences_next_at ON recurrences(next_at);
+
+-- No foreign key on recurrence_id for the same reason as project_id.
+ALTER TABLE tasks ADD COLUMN recurrence_id INTEGER;
+ALTER TABLE tasks ADD COLUMN occurrence_at TEXT;
+
+-- One task per occurrence, so a worker that retries after a crash cannot
+-- create an occurrence twice.
+CREATE UNIQUE INDEX idx_tasks_recurrence_occurrence ON tasks(recurrence_id, occurrence_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_recurrence_occurrence;
+ALTER TABLE tasks DROP COLUMN occurrence_at;
+ALTER TABLE tasks DROP COLUMN recurrence_id;
+DROP TABLE IF EXISTS recurrences;
+-- +goose StatementEnd
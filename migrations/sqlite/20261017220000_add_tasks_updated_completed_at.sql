@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- SQLite cannot add a NOT NULL column without a constant default, the
+-- repository always writes updated_at.
+ALTER TABLE tasks ADD COLUMN updated_at TEXT;
+ALTER TABLE tasks ADD COLUMN completed_at TEXT;
+
+UPDATE tasks SET updated_at = created_at;
+-- When existing tasks were finished is unknown. Counting from the migration
+-- keeps retention rules from purging all of them at once. Statuses 1 and 4
+-- are closed and cancelled.
+UPDATE tasks SET completed_at = strftime('%Y-%m-%d %H:%M:%f000', 'now') WHERE status IN (1, 4);
+
+CREATE INDEX idx_tasks_completed_at ON tasks(completed_at) WHERE completed_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_completed_at;
+ALTER TABLE tasks DROP COLUMN completed_at;
+ALTER TABLE tasks DROP COLUMN updated_at;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Finished tasks moved out of tasks by the cleaner. Rows keep their task id
+-- so a restored task gets it back, and the tag names since task_tags rows
+-- go away with the task.
+CREATE TABLE tasks_archive (
+    id INTEGER PRIMARY KEY,
+    owner_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status INTEGER NOT NULL,
+    priority INTEGER NOT NULL,
+    due_at TEXT,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    completed_at TEXT,
+    -- No foreign keys on parent_id and recurrence_id: the parent or the
+    -- recurrence may be gone by the time the task is restored.
+    parent_id INTEGER,
+    project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL,
+    project_number INTEGER,
+    recurrence_id INTEGER,
+    occurrence_at TEXT,
+    -- Comma separated, tag names never contain commas. NULL for none.
+    tags TEXT,
+    archived_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_tasks_archive_owner_archived_at ON tasks_archive(owner_id, archived_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tasks_archive;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deleted tasks stay in the table until the trash is emptied.
+ALTER TABLE tasks ADD COLUMN deleted_at TEXT;
+
+CREATE INDEX idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+ALTER TABLE tasks DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Audit trail of task changes, written in the transaction of the change.
+-- task_id has no foreign key, events outlive the task. actor_id is NULL
+-- for changes made by background jobs.
+CREATE TABLE task_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id INTEGER NOT NULL,
+    owner_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    kind TEXT NOT NULL,
+    changes TEXT NOT NULL DEFAULT '[]',
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_task_events_task_id ON task_events(task_id, id);
+CREATE INDEX idx_task_events_owner_id ON task_events(owner_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS task_events;
+-- +goose StatementEnd
@@ -0,0 +1,63 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Tasks made before events were recorded get the events that bring them to
+-- their current state: a create with the current fields, the status change
+-- of a task that is no longer open, then the delete of a trashed task or
+-- the archive of an archived one. Earlier edits are unknown, the history
+-- of these tasks starts with their current fields. The create of a
+-- recurring task and the delete and the archive may have been made by a
+-- background job, their actor is NULL.
+INSERT INTO task_events (task_id, owner_id, actor_id, kind, changes, created_at)
+WITH existing AS (
+    SELECT t.id, t.owner_id, t.title, t.description, t.status, t.priority, t.due_at,
+        t.created_at, COALESCE(t.completed_at, t.updated_at, t.created_at) AS finished_at,
+        t.parent_id, t.project_id, t.project_number, t.recurrence_id,
+        (SELECT group_concat(name, ',') FROM (SELECT tg.name FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = t.id ORDER BY tg.name)) AS tags,
+        t.deleted_at, NULL AS archived_at
+    FROM tasks t
+    UNION ALL
+    SELECT a.id, a.owner_id, a.title, a.description, a.status, a.priority, a.due_at,
+        a.created_at, COALESCE(a.completed_at, a.updated_at, a.created_at),
+        a.parent_id, a.project_id, a.project_number, a.recurrence_id,
+        a.tags, NULL, a.archived_at
+    FROM tasks_archive a
+),
+events AS (
+    SELECT e.id AS task_id, e.owner_id,
+        CASE WHEN e.recurrence_id IS NULL THEN e.owner_id END AS actor_id,
+        'create' AS kind,
+        (SELECT json_group_array(json(value)) FROM json_each(json_array(
+            json_object('field', 'title', 'new', e.title),
+            CASE WHEN e.description <> '' THEN json_object('field', 'description', 'new', e.description) END,
+            json_object('field', 'status', 'new', 'not done'),
+            json_object('field', 'priority', 'new', CASE e.priority WHEN 1 THEN 'low' WHEN 2 THEN 'medium' WHEN 3 THEN 'high' WHEN 4 THEN 'critical' END),
+            -- due_at is "2006-01-02 15:04:05.000000" in UTC, events keep RFC 3339.
+            CASE WHEN e.due_at IS NOT NULL THEN json_object('field', 'due', 'new', replace(rtrim(rtrim(e.due_at, '0'), '.'), ' ', 'T') || 'Z') END,
+            CASE WHEN e.tags <> '' THEN json_object('field', 'tags', 'new', e.tags) END,
+            (SELECT json_object('field', 'project', 'new', p.key || '-' || e.project_number) FROM projects p WHERE p.id = e.project_id AND e.project_number IS NOT NULL),
+            CASE WHEN e.parent_id IS NOT NULL THEN json_object('field', 'parent', 'new', CAST(e.parent_id AS TEXT)) END
+        )) WHERE type <> 'null') AS changes,
+        e.created_at, 1 AS step
+    FROM existing e
+    UNION ALL
+    SELECT e.id, e.owner_id, e.owner_id, 'update',
+        json_array(json_object('field', 'status', 'old', 'not done', 'new',
+            CASE e.status WHEN 1 THEN 'done' WHEN 2 THEN 'in progress' WHEN 3 THEN 'blocked' WHEN 4 THEN 'cancelled' END)),
+        max(e.created_at, e.finished_at), 2
+    FROM existing e
+    WHERE e.status <> 0
+    UNION ALL
+    SELECT e.id, e.owner_id, NULL, 'delete', '[]', max(e.created_at, e.finished_at, e.deleted_at), 3
+    FROM existing e
+    WHERE e.deleted_at IS NOT NULL
+    UNION ALL
+    SELECT e.id, e.owner_id, NULL, 'archive', '[]', max(e.created_at, e.finished_at, e.archived_at), 3
+    FROM existing e
+    WHERE e.archived_at IS NOT NULL
+)
+SELECT task_id, owner_id, actor_id, kind, changes, created_at FROM events ORDER BY created_at, step, task_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- The backfilled events cannot be told apart from the recorded ones, they
+-- stay until the task_events table is dropped.
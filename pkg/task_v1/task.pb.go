@@ -0,0 +1,1154 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: task_v1/task.proto
+
+package task_v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TaskStatus int32
+
+const (
+	TaskStatus_TASK_STATUS_UNSPECIFIED TaskStatus = 0
+	TaskStatus_TASK_STATUS_OPEN        TaskStatus = 1
+	TaskStatus_TASK_STATUS_CLOSED      TaskStatus = 2
+	TaskStatus_TASK_STATUS_IN_PROGRESS TaskStatus = 3
+	TaskStatus_TASK_STATUS_BLOCKED     TaskStatus = 4
+	TaskStatus_TASK_STATUS_CANCELLED   TaskStatus = 5
+)
+
+// Enum value maps for TaskStatus.
+var (
+	TaskStatus_name = map[int32]string{
+		0: "TASK_STATUS_UNSPECIFIED",
+		1: "TASK_STATUS_OPEN",
+		2: "TASK_STATUS_CLOSED",
+		3: "TASK_STATUS_IN_PROGRESS",
+		4: "TASK_STATUS_BLOCKED",
+		5: "TASK_STATUS_CANCELLED",
+	}
+	TaskStatus_value = map[string]int32{
+		"TASK_STATUS_UNSPECIFIED": 0,
+		"TASK_STATUS_OPEN":        1,
+		"TASK_STATUS_CLOSED":      2,
+		"TASK_STATUS_IN_PROGRESS": 3,
+		"TASK_STATUS_BLOCKED":     4,
+		"TASK_STATUS_CANCELLED":   5,
+	}
+)
+
+func (x TaskStatus) Enum() *TaskStatus {
+	p := new(TaskStatus)
+	*p = x
+	return p
+}
+
+func (x TaskStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_v1_task_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskStatus) Type() protoreflect.EnumType {
+	return &file_task_v1_task_proto_enumTypes[0]
+}
+
+func (x TaskStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskStatus.Descriptor instead.
+func (TaskStatus) EnumDescriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{0}
+}
+
+// Values match model.TaskPriority.
+type TaskPriority int32
+
+const (
+	TaskPriority_TASK_PRIORITY_UNSPECIFIED TaskPriority = 0
+	TaskPriority_TASK_PRIORITY_LOW         TaskPriority = 1
+	TaskPriority_TASK_PRIORITY_MEDIUM      TaskPriority = 2
+	TaskPriority_TASK_PRIORITY_HIGH        TaskPriority = 3
+	TaskPriority_TASK_PRIORITY_CRITICAL    TaskPriority = 4
+)
+
+// Enum value maps for TaskPriority.
+var (
+	TaskPriority_name = map[int32]string{
+		0: "TASK_PRIORITY_UNSPECIFIED",
+		1: "TASK_PRIORITY_LOW",
+		2: "TASK_PRIORITY_MEDIUM",
+		3: "TASK_PRIORITY_HIGH",
+		4: "TASK_PRIORITY_CRITICAL",
+	}
+	TaskPriority_value = map[string]int32{
+		"TASK_PRIORITY_UNSPECIFIED": 0,
+		"TASK_PRIORITY_LOW":         1,
+		"TASK_PRIORITY_MEDIUM":      2,
+		"TASK_PRIORITY_HIGH":        3,
+		"TASK_PRIORITY_CRITICAL":    4,
+	}
+)
+
+func (x TaskPriority) Enum() *TaskPriority {
+	p := new(TaskPriority)
+	*p = x
+	return p
+}
+
+func (x TaskPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_v1_task_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskPriority) Type() protoreflect.EnumType {
+	return &file_task_v1_task_proto_enumTypes[1]
+}
+
+func (x TaskPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskPriority.Descriptor instead.
+func (TaskPriority) EnumDescriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{1}
+}
+
+// Values match model.ChildPolicy.
+type ChildPolicy int32
+
+const (
+	// Refuse to delete a task that has subtasks.
+	ChildPolicy_CHILD_POLICY_REFUSE ChildPolicy = 0
+	// Delete the subtasks too.
+	ChildPolicy_CHILD_POLICY_CASCADE ChildPolicy = 1
+	// Move the subtasks to the parent of the deleted task.
+	ChildPolicy_CHILD_POLICY_REPARENT ChildPolicy = 2
+)
+
+// Enum value maps for ChildPolicy.
+var (
+	ChildPolicy_name = map[int32]string{
+		0: "CHILD_POLICY_REFUSE",
+		1: "CHILD_POLICY_CASCADE",
+		2: "CHILD_POLICY_REPARENT",
+	}
+	ChildPolicy_value = map[string]int32{
+		"CHILD_POLICY_REFUSE":   0,
+		"CHILD_POLICY_CASCADE":  1,
+		"CHILD_POLICY_REPARENT": 2,
+	}
+)
+
+func (x ChildPolicy) Enum() *ChildPolicy {
+	p := new(ChildPolicy)
+	*p = x
+	return p
+}
+
+func (x ChildPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChildPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_v1_task_proto_enumTypes[2].Descriptor()
+}
+
+func (ChildPolicy) Type() protoreflect.EnumType {
+	return &file_task_v1_task_proto_enumTypes[2]
+}
+
+func (x ChildPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChildPolicy.Descriptor instead.
+func (ChildPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{2}
+}
+
+type Task struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status      TaskStatus             `protobuf:"varint,4,opt,name=status,proto3,enum=task_v1.TaskStatus" json:"status,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DueAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Overdue     bool                   `protobuf:"varint,7,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	Priority    TaskPriority           `protobuf:"varint,8,opt,name=priority,proto3,enum=task_v1.TaskPriority" json:"priority,omitempty"`
+	Tags        []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Empty for tasks outside projects.
+	Project string `protobuf:"bytes,10,opt,name=project,proto3" json:"project,omitempty"`
+	// INFRA-12 for tasks in a project, the plain id otherwise.
+	DisplayId string `protobuf:"bytes,11,opt,name=display_id,json=displayId,proto3" json:"display_id,omitempty"`
+	// Zero for top level tasks.
+	ParentId int64 `protobuf:"varint,12,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Number of unfinished tasks this one depends on.
+	OpenDependencies int32                  `protobuf:"varint,13,opt,name=open_dependencies,json=openDependencies,proto3" json:"open_dependencies,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Unset while the task is not finished.
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_task_v1_task_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_task_v1_task_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *Task) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+func (x *Task) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *Task) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Task) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *Task) GetDisplayId() string {
+	if x != nil {
+		return x.DisplayId
+	}
+	return ""
+}
+
+func (x *Task) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Task) GetOpenDependencies() int32 {
+	if x != nil {
+		return x.OpenDependencies
+	}
+	return 0
+}
+
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+// TagList wraps tags where an absent list and an empty one differ.
+type TagList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagList) Reset() {
+	*x = TagList{}
+	mi := &file_task_v1_task_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagList) ProtoMessage() {}
+
+func (x *TagList) ProtoReflect() protoreflect.Message {
+	mi := &file_task_v1_task_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagList.ProtoReflect.Descriptor instead.
+func (*TagList) Descriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TagList) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type CreateTaskRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	DueAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	// Leave unspecified for medium.
+	Priority TaskPriority `protobuf:"varint,4,opt,name=priority,proto3,enum=task_v1.TaskPriority" json:"priority,omitempty"`
+	Tags     []string     `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Project key, leave empty for a task outside projects.
+	Project string `protobuf:"bytes,6,opt,name=project,proto3" json:"project,omitempty"`
+	// Makes the task a subtask, leave zero for a top level task.
+	ParentId      int64 `protobuf:"varint,7,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_task_v1_task_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_v1_task_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *CreateTaskRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+type CreateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskResponse) Reset() {
+	*x = CreateTaskResponse{}
+	mi := &file_task_v1_task_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskResponse) ProtoMessage() {}
+
+func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_v1_task_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
+func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_task_v1_task_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_v1_task_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskResponse) Reset() {
+	*x = GetTaskResponse{}
+	mi := &file_task_v1_task_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskResponse) ProtoMessage() {}
+
+func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_v1_task_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type ListTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Leave unspecified to list tasks in any status.
+	Status TaskStatus `protobuf:"varint,1,opt,name=status,proto3,enum=task_v1.TaskStatus" json:"status,omitempty"`
+	// Only unfinished tasks past their due date.
+	Overdue   bool                   `protobuf:"varint,2,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	DueBefore *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=due_before,json=dueBefore,proto3" json:"due_before,omitempty"`
+	DueAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=due_after,json=dueAfter,proto3" json:"due_after,omitempty"`
+	// Comma separated sort keys: priority, due, created. A leading "-"
+	// reverses a key. Empty means newest first.
+	Sort string `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Only tasks carrying any of the tags, or all of them with all_tags.
+	Tags    []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	AllTags bool     `protobuf:"varint,7,opt,name=all_tags,json=allTags,proto3" json:"all_tags,omitempty"`
+	// Only tasks of the project with this key.
+	Project       string `protobuf:"bytes,8,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_task_v1_task_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_v1_task_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTasksRequest) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *ListTasksRequest) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+func (x *ListTasksRequest) GetDueBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueBefore
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetDueAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAfter
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetAllTags() bool {
+	if x != nil {
+		return x.AllTags
+	}
+	return false
+}
+
+func (x *ListTasksRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+type UpdateTaskRequest struct {
+	state       protoimpl.MessageState  `protogen:"open.v1"`
+	Id          int64                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Leave unspecified to keep the current status.
+	Status TaskStatus             `protobuf:"varint,4,opt,name=status,proto3,enum=task_v1.TaskStatus" json:"status,omitempty"`
+	DueAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	// Removes the due date, due_at is ignored when set.
+	ClearDue bool `protobuf:"varint,6,opt,name=clear_due,json=clearDue,proto3" json:"clear_due,omitempty"`
+	// Leave unspecified to keep the current priority.
+	Priority TaskPriority `protobuf:"varint,7,opt,name=priority,proto3,enum=task_v1.TaskPriority" json:"priority,omitempty"`
+	// Replaces the task tags when set, an empty list removes them all.
+	Tags *TagList `protobuf:"bytes,8,opt,name=tags,proto3" json:"tags,omitempty"`
+	// Moves the task to the project with this key, an empty key takes it out.
+	Project *wrapperspb.StringValue `protobuf:"bytes,9,opt,name=project,proto3" json:"project,omitempty"`
+	// Moves the task under another task when set, zero makes it top level.
+	ParentId *wrapperspb.Int64Value `protobuf:"bytes,10,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Finish the task even if some of its subtasks are still open.
+	Force         bool `protobuf:"varint,11,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_task_v1_task_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_v1_task_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetTitle() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Title
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetDescription() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Description
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateTaskRequest) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetClearDue() bool {
+	if x != nil {
+		return x.ClearDue
+	}
+	return false
+}
+
+func (x *UpdateTaskRequest) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *UpdateTaskRequest) GetTags() *TagList {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetProject() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetParentId() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ParentId
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type UpdateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskResponse) Reset() {
+	*x = UpdateTaskResponse{}
+	mi := &file_task_v1_task_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskResponse) ProtoMessage() {}
+
+func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_v1_task_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Children      ChildPolicy            `protobuf:"varint,2,opt,name=children,proto3,enum=task_v1.ChildPolicy" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_task_v1_task_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_v1_task_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_task_v1_task_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteTaskRequest) GetChildren() ChildPolicy {
+	if x != nil {
+		return x.Children
+	}
+	return ChildPolicy_CHILD_POLICY_REFUSE
+}
+
+var File_task_v1_task_proto protoreflect.FileDescriptor
+
+const file_task_v1_task_proto_rawDesc = "" +
+	"\n" +
+	"\x12task_v1/task.proto\x12\atask_v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\"\xc7\x04\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12+\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x13.task_v1.TaskStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x121\n" +
+	"\x06due_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12\x18\n" +
+	"\aoverdue\x18\a \x01(\bR\aoverdue\x121\n" +
+	"\bpriority\x18\b \x01(\x0e2\x15.task_v1.TaskPriorityR\bpriority\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12\x18\n" +
+	"\aproject\x18\n" +
+	" \x01(\tR\aproject\x12\x1d\n" +
+	"\n" +
+	"display_id\x18\v \x01(\tR\tdisplayId\x12\x1b\n" +
+	"\tparent_id\x18\f \x01(\x03R\bparentId\x12+\n" +
+	"\x11open_dependencies\x18\r \x01(\x05R\x10openDependencies\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\x1d\n" +
+	"\aTagList\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"\xfc\x01\n" +
+	"\x11CreateTaskRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x121\n" +
+	"\x06due_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x121\n" +
+	"\bpriority\x18\x04 \x01(\x0e2\x15.task_v1.TaskPriorityR\bpriority\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x18\n" +
+	"\aproject\x18\x06 \x01(\tR\aproject\x12\x1b\n" +
+	"\tparent_id\x18\a \x01(\x03R\bparentId\"7\n" +
+	"\x12CreateTaskResponse\x12!\n" +
+	"\x04task\x18\x01 \x01(\v2\r.task_v1.TaskR\x04task\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"4\n" +
+	"\x0fGetTaskResponse\x12!\n" +
+	"\x04task\x18\x01 \x01(\v2\r.task_v1.TaskR\x04task\"\xaa\x02\n" +
+	"\x10ListTasksRequest\x12+\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x13.task_v1.TaskStatusR\x06status\x12\x18\n" +
+	"\aoverdue\x18\x02 \x01(\bR\aoverdue\x129\n" +
+	"\n" +
+	"due_before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tdueBefore\x127\n" +
+	"\tdue_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bdueAfter\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x19\n" +
+	"\ball_tags\x18\a \x01(\bR\aallTags\x12\x18\n" +
+	"\aproject\x18\b \x01(\tR\aproject\"\xf5\x03\n" +
+	"\x11UpdateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x122\n" +
+	"\x05title\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueR\x05title\x12>\n" +
+	"\vdescription\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\vdescription\x12+\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x13.task_v1.TaskStatusR\x06status\x121\n" +
+	"\x06due_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12\x1b\n" +
+	"\tclear_due\x18\x06 \x01(\bR\bclearDue\x121\n" +
+	"\bpriority\x18\a \x01(\x0e2\x15.task_v1.TaskPriorityR\bpriority\x12$\n" +
+	"\x04tags\x18\b \x01(\v2\x10.task_v1.TagListR\x04tags\x126\n" +
+	"\aproject\x18\t \x01(\v2\x1c.google.protobuf.StringValueR\aproject\x128\n" +
+	"\tparent_id\x18\n" +
+	" \x01(\v2\x1b.google.protobuf.Int64ValueR\bparentId\x12\x14\n" +
+	"\x05force\x18\v \x01(\bR\x05force\"7\n" +
+	"\x12UpdateTaskResponse\x12!\n" +
+	"\x04task\x18\x01 \x01(\v2\r.task_v1.TaskR\x04task\"U\n" +
+	"\x11DeleteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x120\n" +
+	"\bchildren\x18\x02 \x01(\x0e2\x14.task_v1.ChildPolicyR\bchildren*\xa8\x01\n" +
+	"\n" +
+	"TaskStatus\x12\x1b\n" +
+	"\x17TASK_STATUS_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10TASK_STATUS_OPEN\x10\x01\x12\x16\n" +
+	"\x12TASK_STATUS_CLOSED\x10\x02\x12\x1b\n" +
+	"\x17TASK_STATUS_IN_PROGRESS\x10\x03\x12\x17\n" +
+	"\x13TASK_STATUS_BLOCKED\x10\x04\x12\x19\n" +
+	"\x15TASK_STATUS_CANCELLED\x10\x05*\x92\x01\n" +
+	"\fTaskPriority\x12\x1d\n" +
+	"\x19TASK_PRIORITY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11TASK_PRIORITY_LOW\x10\x01\x12\x18\n" +
+	"\x14TASK_PRIORITY_MEDIUM\x10\x02\x12\x16\n" +
+	"\x12TASK_PRIORITY_HIGH\x10\x03\x12\x1a\n" +
+	"\x16TASK_PRIORITY_CRITICAL\x10\x04*[\n" +
+	"\vChildPolicy\x12\x17\n" +
+	"\x13CHILD_POLICY_REFUSE\x10\x00\x12\x18\n" +
+	"\x14CHILD_POLICY_CASCADE\x10\x01\x12\x19\n" +
+	"\x15CHILD_POLICY_REPARENT\x10\x022\xcf\x02\n" +
+	"\x06TaskV1\x12E\n" +
+	"\n" +
+	"CreateTask\x12\x1a.task_v1.CreateTaskRequest\x1a\x1b.task_v1.CreateTaskResponse\x12<\n" +
+	"\aGetTask\x12\x17.task_v1.GetTaskRequest\x1a\x18.task_v1.GetTaskResponse\x127\n" +
+	"\tListTasks\x12\x19.task_v1.ListTasksRequest\x1a\r.task_v1.Task0\x01\x12E\n" +
+	"\n" +
+	"UpdateTask\x12\x1a.task_v1.UpdateTaskRequest\x1a\x1b.task_v1.UpdateTaskResponse\x12@\n" +
+	"\n" +
+	"DeleteTask\x12\x1a.task_v1.DeleteTaskRequest\x1a\x16.google.protobuf.EmptyB\x1cZ\x1atechno/pkg/task_v1;task_v1b\x06proto3"
+
+var (
+	file_task_v1_task_proto_rawDescOnce sync.Once
+	file_task_v1_task_proto_rawDescData []byte
+)
+
+func file_task_v1_task_proto_rawDescGZIP() []byte {
+	file_task_v1_task_proto_rawDescOnce.Do(func() {
+		file_task_v1_task_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_task_v1_task_proto_rawDesc), len(file_task_v1_task_proto_rawDesc)))
+	})
+	return file_task_v1_task_proto_rawDescData
+}
+
+var file_task_v1_task_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_task_v1_task_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_task_v1_task_proto_goTypes = []any{
+	(TaskStatus)(0),                // 0: task_v1.TaskStatus
+	(TaskPriority)(0),              // 1: task_v1.TaskPriority
+	(ChildPolicy)(0),               // 2: task_v1.ChildPolicy
+	(*Task)(nil),                   // 3: task_v1.Task
+	(*TagList)(nil),                // 4: task_v1.TagList
+	(*CreateTaskRequest)(nil),      // 5: task_v1.CreateTaskRequest
+	(*CreateTaskResponse)(nil),     // 6: task_v1.CreateTaskResponse
+	(*GetTaskRequest)(nil),         // 7: task_v1.GetTaskRequest
+	(*GetTaskResponse)(nil),        // 8: task_v1.GetTaskResponse
+	(*ListTasksRequest)(nil),       // 9: task_v1.ListTasksRequest
+	(*UpdateTaskRequest)(nil),      // 10: task_v1.UpdateTaskRequest
+	(*UpdateTaskResponse)(nil),     // 11: task_v1.UpdateTaskResponse
+	(*DeleteTaskRequest)(nil),      // 12: task_v1.DeleteTaskRequest
+	(*timestamppb.Timestamp)(nil),  // 13: google.protobuf.Timestamp
+	(*wrapperspb.StringValue)(nil), // 14: google.protobuf.StringValue
+	(*wrapperspb.Int64Value)(nil),  // 15: google.protobuf.Int64Value
+	(*emptypb.Empty)(nil),          // 16: google.protobuf.Empty
+}
+var file_task_v1_task_proto_depIdxs = []int32{
+	0,  // 0: task_v1.Task.status:type_name -> task_v1.TaskStatus
+	13, // 1: task_v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	13, // 2: task_v1.Task.due_at:type_name -> google.protobuf.Timestamp
+	1,  // 3: task_v1.Task.priority:type_name -> task_v1.TaskPriority
+	13, // 4: task_v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 5: task_v1.Task.completed_at:type_name -> google.protobuf.Timestamp
+	13, // 6: task_v1.CreateTaskRequest.due_at:type_name -> google.protobuf.Timestamp
+	1,  // 7: task_v1.CreateTaskRequest.priority:type_name -> task_v1.TaskPriority
+	3,  // 8: task_v1.CreateTaskResponse.task:type_name -> task_v1.Task
+	3,  // 9: task_v1.GetTaskResponse.task:type_name -> task_v1.Task
+	0,  // 10: task_v1.ListTasksRequest.status:type_name -> task_v1.TaskStatus
+	13, // 11: task_v1.ListTasksRequest.due_before:type_name -> google.protobuf.Timestamp
+	13, // 12: task_v1.ListTasksRequest.due_after:type_name -> google.protobuf.Timestamp
+	14, // 13: task_v1.UpdateTaskRequest.title:type_name -> google.protobuf.StringValue
+	14, // 14: task_v1.UpdateTaskRequest.description:type_name -> google.protobuf.StringValue
+	0,  // 15: task_v1.UpdateTaskRequest.status:type_name -> task_v1.TaskStatus
+	13, // 16: task_v1.UpdateTaskRequest.due_at:type_name -> google.protobuf.Timestamp
+	1,  // 17: task_v1.UpdateTaskRequest.priority:type_name -> task_v1.TaskPriority
+	4,  // 18: task_v1.UpdateTaskRequest.tags:type_name -> task_v1.TagList
+	14, // 19: task_v1.UpdateTaskRequest.project:type_name -> google.protobuf.StringValue
+	15, // 20: task_v1.UpdateTaskRequest.parent_id:type_name -> google.protobuf.Int64Value
+	3,  // 21: task_v1.UpdateTaskResponse.task:type_name -> task_v1.Task
+	2,  // 22: task_v1.DeleteTaskRequest.children:type_name -> task_v1.ChildPolicy
+	5,  // 23: task_v1.TaskV1.CreateTask:input_type -> task_v1.CreateTaskRequest
+	7,  // 24: task_v1.TaskV1.GetTask:input_type -> task_v1.GetTaskRequest
+	9,  // 25: task_v1.TaskV1.ListTasks:input_type -> task_v1.ListTasksRequest
+	10, // 26: task_v1.TaskV1.UpdateTask:input_type -> task_v1.UpdateTaskRequest
+	12, // 27: task_v1.TaskV1.DeleteTask:input_type -> task_v1.DeleteTaskRequest
+	6,  // 28: task_v1.TaskV1.CreateTask:output_type -> task_v1.CreateTaskResponse
+	8,  // 29: task_v1.TaskV1.GetTask:output_type -> task_v1.GetTaskResponse
+	3,  // 30: task_v1.TaskV1.ListTasks:output_type -> task_v1.Task
+	11, // 31: task_v1.TaskV1.UpdateTask:output_type -> task_v1.UpdateTaskResponse
+	16, // 32: task_v1.TaskV1.DeleteTask:output_type -> google.protobuf.Empty
+	28, // [28:33] is the sub-list for method output_type
+	23, // [23:28] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_task_v1_task_proto_init() }
+func file_task_v1_task_proto_init() {
+	if File_task_v1_task_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_task_v1_task_proto_rawDesc), len(file_task_v1_task_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_task_v1_task_proto_goTypes,
+		DependencyIndexes: file_task_v1_task_proto_depIdxs,
+		EnumInfos:         file_task_v1_task_proto_enumTypes,
+		MessageInfos:      file_task_v1_task_proto_msgTypes,
+	}.Build()
+	File_task_v1_task_proto = out.File
+	file_task_v1_task_proto_goTypes = nil
+	file_task_v1_task_proto_depIdxs = nil
+}